@@ -0,0 +1,32 @@
+package computed
+
+import (
+	"errors"
+	"time"
+
+	"github.com/underbek/datamapper/_test_data/mapper/computed/domain"
+)
+
+// FullName returns first and last names of user
+func FullName(from domain.User) string {
+	return from.FirstName + " " + from.LastName
+}
+
+// Initials returns first letters of first and last names of user
+func Initials(from domain.User) string {
+	if from.FirstName == "" || from.LastName == "" {
+		return ""
+	}
+
+	return from.FirstName[:1] + from.LastName[:1]
+}
+
+// Age returns age of user by birth year, birth year must not be in the future
+func Age(from *domain.User) (int, error) {
+	age := time.Now().Year() - from.BirthYear
+	if age < 0 {
+		return 0, errors.New("birth year is in the future")
+	}
+
+	return age, nil
+}
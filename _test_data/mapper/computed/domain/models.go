@@ -0,0 +1,13 @@
+package domain
+
+type User struct {
+	ID        int    `map:"id"`
+	FirstName string `map:"first_name"`
+	LastName  string `map:"last_name"`
+	BirthYear int    `map:"birth_year"`
+}
+
+type Account struct {
+	ID    int  `map:"id"`
+	Owner User `map:"owner"`
+}
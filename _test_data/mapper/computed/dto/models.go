@@ -0,0 +1,13 @@
+package dto
+
+type Profile struct {
+	ID       string `json:"id"`
+	FullName string `json:"full_name,omitempty,compute=FullName"`
+	Initials string `json:"initials"`
+	Age      int    `json:"age,compute=Age"`
+}
+
+type Account struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+}
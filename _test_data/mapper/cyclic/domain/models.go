@@ -0,0 +1,19 @@
+package domain
+
+type Category struct {
+	ID       int64       `map:"id"`
+	Name     string      `map:"name"`
+	Parent   *Category   `map:"parent"`
+	Children []*Category `map:"children"`
+}
+
+type User struct {
+	Name string `map:"name"`
+	Team *Team  `map:"team"`
+}
+
+type Team struct {
+	ID      int64  `map:"id"`
+	Title   string `map:"title"`
+	Members []User `map:"members"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+type Category struct {
+	ID       string      `map:"id"`
+	Name     string      `map:"name"`
+	Parent   *Category   `map:"parent"`
+	Children []*Category `map:"children"`
+}
+
+type User struct {
+	Name string `map:"name"`
+	Team *Team  `map:"team"`
+}
+
+type Team struct {
+	ID      string `map:"id"`
+	Title   string `map:"title"`
+	Members []User `map:"members"`
+}
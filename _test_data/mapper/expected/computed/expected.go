@@ -0,0 +1,29 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/computed/computed"
+	"github.com/underbek/datamapper/_test_data/mapper/computed/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/computed/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainUserToDtoProfile convert domain.User by tag map to dto.Profile by tag json
+func ConvertDomainUserToDtoProfile(from domain.User) (dto.Profile, error) {
+	computedAge, err := computed.Age(&from)
+	if err != nil {
+		return dto.Profile{}, fmt.Errorf("compute Profile.Age failed: %w", err)
+	}
+
+	return dto.Profile{
+		ID:       converts.ConvertNumericToString(from.ID),
+		FullName: computed.FullName(from),
+		Initials: computed.Initials(from),
+		Age:      computedAge,
+	}, nil
+}
@@ -0,0 +1,80 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/cyclic/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/cyclic/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainCategoryToDtoCategory convert domain.Category by tag map to dto.Category by tag map
+func ConvertDomainCategoryToDtoCategory(from domain.Category) dto.Category {
+	var fromParent *dto.Category
+	if from.Parent != nil {
+		res := ConvertDomainCategoryToDtoCategory(*from.Parent)
+		fromParent = &res
+	}
+
+	fromChildren := make([]*dto.Category, 0, len(from.Children))
+	for _, item := range from.Children {
+		var resPtr *dto.Category
+		if item != nil {
+			res := ConvertDomainCategoryToDtoCategory(*item)
+			resPtr = &res
+		}
+
+		fromChildren = append(fromChildren, resPtr)
+	}
+
+	return dto.Category{
+		ID:       converts.ConvertNumericToString(from.ID),
+		Name:     from.Name,
+		Parent:   fromParent,
+		Children: fromChildren,
+	}
+}
+
+// ConvertDtoCategoryToDomainCategory convert dto.Category by tag map to domain.Category by tag map
+func ConvertDtoCategoryToDomainCategory(from dto.Category) (domain.Category, error) {
+	fromID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domain.Category{}, fmt.Errorf("convert Category.ID -> Category.ID failed: %w", err)
+	}
+
+	var fromParent *domain.Category
+	if from.Parent != nil {
+		res, err := ConvertDtoCategoryToDomainCategory(*from.Parent)
+		if err != nil {
+			return domain.Category{}, fmt.Errorf("convert Category.Parent -> Category.Parent failed: %w", err)
+		}
+
+		fromParent = &res
+	}
+
+	fromChildren := make([]*domain.Category, 0, len(from.Children))
+	for _, item := range from.Children {
+		var resPtr *domain.Category
+		if item != nil {
+			res, err := ConvertDtoCategoryToDomainCategory(*item)
+			if err != nil {
+				return domain.Category{}, fmt.Errorf("convert Category.Children -> Category.Children failed: %w", err)
+			}
+
+			resPtr = &res
+		}
+
+		fromChildren = append(fromChildren, resPtr)
+	}
+
+	return domain.Category{
+		ID:       fromID,
+		Name:     from.Name,
+		Parent:   fromParent,
+		Children: fromChildren,
+	}, nil
+}
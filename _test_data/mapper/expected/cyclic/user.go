@@ -0,0 +1,83 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/cyclic/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/cyclic/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainTeamToDtoTeam convert domain.Team by tag map to dto.Team by tag map
+func ConvertDomainTeamToDtoTeam(from domain.Team) dto.Team {
+	fromMembers := make([]dto.User, 0, len(from.Members))
+	for _, item := range from.Members {
+		fromMembers = append(fromMembers, ConvertDomainUserToDtoUser(item))
+	}
+
+	return dto.Team{
+		ID:      converts.ConvertNumericToString(from.ID),
+		Title:   from.Title,
+		Members: fromMembers,
+	}
+}
+
+// ConvertDtoTeamToDomainTeam convert dto.Team by tag map to domain.Team by tag map
+func ConvertDtoTeamToDomainTeam(from dto.Team) (domain.Team, error) {
+	fromID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domain.Team{}, fmt.Errorf("convert Team.ID -> Team.ID failed: %w", err)
+	}
+
+	fromMembers := make([]domain.User, 0, len(from.Members))
+	for _, item := range from.Members {
+		res, err := ConvertDtoUserToDomainUser(item)
+		if err != nil {
+			return domain.Team{}, fmt.Errorf("convert Team.Members -> Team.Members failed: %w", err)
+		}
+
+		fromMembers = append(fromMembers, res)
+	}
+
+	return domain.Team{
+		ID:      fromID,
+		Title:   from.Title,
+		Members: fromMembers,
+	}, nil
+}
+
+// ConvertDomainUserToDtoUser convert domain.User by tag map to dto.User by tag map
+func ConvertDomainUserToDtoUser(from domain.User) dto.User {
+	var fromTeam *dto.Team
+	if from.Team != nil {
+		res := ConvertDomainTeamToDtoTeam(*from.Team)
+		fromTeam = &res
+	}
+
+	return dto.User{
+		Name: from.Name,
+		Team: fromTeam,
+	}
+}
+
+// ConvertDtoUserToDomainUser convert dto.User by tag map to domain.User by tag map
+func ConvertDtoUserToDomainUser(from dto.User) (domain.User, error) {
+	var fromTeam *domain.Team
+	if from.Team != nil {
+		res, err := ConvertDtoTeamToDomainTeam(*from.Team)
+		if err != nil {
+			return domain.User{}, fmt.Errorf("convert User.Team -> User.Team failed: %w", err)
+		}
+
+		fromTeam = &res
+	}
+
+	return domain.User{
+		Name: from.Name,
+		Team: fromTeam,
+	}, nil
+}
@@ -0,0 +1,42 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/merge/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/merge/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainAddressToDtoAddress convert domain.Address by tag map to dto.Address by tag json
+func ConvertDomainAddressToDtoAddress(from domain.Address) dto.Address {
+	return dto.Address{
+		City:   from.City,
+		Street: from.Street,
+	}
+}
+
+// ConvertDomainUserDomainAccountToDtoProfile convert domain.User by tag map, *domain.Account by tag profile to dto.Profile by tag json
+func ConvertDomainUserDomainAccountToDtoProfile(user domain.User, account *domain.Account) (dto.Profile, error) {
+	if account == nil {
+		return dto.Profile{}, errors.New("Account is nil")
+	}
+
+	userID, err := converts.ConvertStringToSigned[int64](user.ID)
+	if err != nil {
+		return dto.Profile{}, fmt.Errorf("convert User.ID -> Profile.ID failed: %w", err)
+	}
+
+	return dto.Profile{
+		ID:        userID,
+		Name:      user.Name,
+		AccountID: account.ID,
+		Balance:   converts.ConvertDecimalToString(account.Balance),
+		Address:   ConvertDomainAddressToDtoAddress(user.Address),
+	}, nil
+}
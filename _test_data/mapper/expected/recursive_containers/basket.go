@@ -0,0 +1,155 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+
+	"github.com/underbek/datamapper/_test_data/mapper/recursive_containers/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/recursive_containers/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainItemToDtoItem convert domain.Item by tag map to dto.Item by tag map
+func ConvertDomainItemToDtoItem(from domain.Item) dto.Item {
+	return dto.Item{
+		Code:  from.Code,
+		Price: converts.ConvertOrderedToOrdered[float64, float32](from.Price),
+	}
+}
+
+// ConvertDtoItemToDomainItem convert dto.Item by tag map to domain.Item by tag map
+func ConvertDtoItemToDomainItem(from dto.Item) domain.Item {
+	return domain.Item{
+		Code:  from.Code,
+		Price: converts.ConvertOrderedToOrdered[float32, float64](from.Price),
+	}
+}
+
+// ConvertDomainBasketToDtoBasket convert domain.Basket by tag map to dto.Basket by tag map
+func ConvertDomainBasketToDtoBasket(from domain.Basket) (dto.Basket, error) {
+	fromItems := make([]*dto.Item, 0, len(from.Items))
+	for _, item := range from.Items {
+		res := ConvertDomainItemToDtoItem(item)
+
+		fromItems = append(fromItems, &res)
+	}
+
+	var fromPinned [2]dto.Item
+	for i, item := range from.Pinned {
+		fromPinned[i] = ConvertDomainItemToDtoItem(item)
+	}
+
+	fromByCode := make(map[string]*dto.Item, len(from.ByCode))
+	for key, item := range from.ByCode {
+		res := ConvertDomainItemToDtoItem(item)
+
+		fromByCode[key] = &res
+	}
+
+	var fromGift *dto.Item
+	if from.Gift != nil {
+		res := ConvertDomainItemToDtoItem(*from.Gift)
+		fromGift = &res
+	}
+
+	if from.Archived == nil {
+		return dto.Basket{}, errors.New("cannot convert domain.Basket.Archived -> dto.Basket.Archived, field is nil")
+	}
+
+	fromArchived := make([]dto.Item, 0, len(*from.Archived))
+	for _, item := range *from.Archived {
+		fromArchived = append(fromArchived, ConvertDomainItemToDtoItem(item))
+	}
+
+	var fromSaved *[]*dto.Item
+	if from.Saved != nil {
+		fromSavedValue := make([]*dto.Item, 0, len(*from.Saved))
+		for _, item := range *from.Saved {
+			var resPtr *dto.Item
+			if item != nil {
+				res := ConvertDomainItemToDtoItem(*item)
+				resPtr = &res
+			}
+
+			fromSavedValue = append(fromSavedValue, resPtr)
+		}
+
+		fromSaved = &fromSavedValue
+	}
+
+	return dto.Basket{
+		ID:       from.ID,
+		Items:    fromItems,
+		Pinned:   fromPinned,
+		ByCode:   fromByCode,
+		Gift:     fromGift,
+		Archived: fromArchived,
+		Saved:    fromSaved,
+	}, nil
+}
+
+// ConvertDtoBasketToDomainBasket convert dto.Basket by tag map to domain.Basket by tag map
+func ConvertDtoBasketToDomainBasket(from dto.Basket) (domain.Basket, error) {
+	fromItems := make([]domain.Item, 0, len(from.Items))
+	for _, item := range from.Items {
+		if item == nil {
+			return domain.Basket{}, errors.New("cannot convert dto.Basket.Items -> domain.Basket.Items, field is nil")
+		}
+
+		fromItems = append(fromItems, ConvertDtoItemToDomainItem(*item))
+	}
+
+	var fromPinned [2]domain.Item
+	for i, item := range from.Pinned {
+		fromPinned[i] = ConvertDtoItemToDomainItem(item)
+	}
+
+	fromByCode := make(map[string]domain.Item, len(from.ByCode))
+	for key, item := range from.ByCode {
+		if item == nil {
+			return domain.Basket{}, errors.New("cannot convert dto.Basket.ByCode -> domain.Basket.ByCode, field is nil")
+		}
+
+		fromByCode[key] = ConvertDtoItemToDomainItem(*item)
+	}
+
+	var fromGift *domain.Item
+	if from.Gift != nil {
+		res := ConvertDtoItemToDomainItem(*from.Gift)
+		fromGift = &res
+	}
+
+	fromArchived := make([]domain.Item, 0, len(from.Archived))
+	for _, item := range from.Archived {
+		fromArchived = append(fromArchived, ConvertDtoItemToDomainItem(item))
+	}
+
+	var fromSaved *[]*domain.Item
+	if from.Saved != nil {
+		fromSavedValue := make([]*domain.Item, 0, len(*from.Saved))
+		for _, item := range *from.Saved {
+			var resPtr *domain.Item
+			if item != nil {
+				res := ConvertDtoItemToDomainItem(*item)
+				resPtr = &res
+			}
+
+			fromSavedValue = append(fromSavedValue, resPtr)
+		}
+
+		fromSaved = &fromSavedValue
+	}
+
+	return domain.Basket{
+		ID:       from.ID,
+		Items:    fromItems,
+		Pinned:   fromPinned,
+		ByCode:   fromByCode,
+		Gift:     fromGift,
+		Archived: &fromArchived,
+		Saved:    fromSaved,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/recursive_packages/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/recursive_packages/transport"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainOrderToTransportOrder convert domain.Order by tag map to transport.Order by tag map
+func ConvertDomainOrderToTransportOrder(from domain.Order) transport.Order {
+	fromUser := ConvertDomainuserUserToTransportuserUser(from.User)
+
+	return transport.Order{
+		ID:   converts.ConvertNumericToString(from.ID),
+		User: &fromUser,
+	}
+}
+
+// ConvertTransportOrderToDomainOrder convert transport.Order by tag map to domain.Order by tag map
+func ConvertTransportOrderToDomainOrder(from transport.Order) (domain.Order, error) {
+	fromID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domain.Order{}, fmt.Errorf("convert Order.ID -> Order.ID failed: %w", err)
+	}
+
+	if from.User == nil {
+		return domain.Order{}, errors.New("cannot convert transport.Order.User -> domain.Order.User, field is nil")
+	}
+
+	fromUser, err := ConvertTransportuserUserToDomainuserUser(*from.User)
+	if err != nil {
+		return domain.Order{}, fmt.Errorf("convert Order.User -> Order.User failed: %w", err)
+	}
+
+	return domain.Order{
+		ID:   fromID,
+		User: fromUser,
+	}, nil
+}
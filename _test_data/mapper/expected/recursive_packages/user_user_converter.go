@@ -0,0 +1,34 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+
+	domainuser "github.com/underbek/datamapper/_test_data/mapper/recursive_packages/domain/user"
+	transportuser "github.com/underbek/datamapper/_test_data/mapper/recursive_packages/transport/user"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainuserUserToTransportuserUser convert domainuser.User by tag map to transportuser.User by tag map
+func ConvertDomainuserUserToTransportuserUser(from domainuser.User) transportuser.User {
+	return transportuser.User{
+		ID:   converts.ConvertNumericToString(from.ID),
+		Name: from.Name,
+	}
+}
+
+// ConvertTransportuserUserToDomainuserUser convert transportuser.User by tag map to domainuser.User by tag map
+func ConvertTransportuserUserToDomainuserUser(from transportuser.User) (domainuser.User, error) {
+	fromID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domainuser.User{}, fmt.Errorf("convert User.ID -> User.ID failed: %w", err)
+	}
+
+	return domainuser.User{
+		ID:   fromID,
+		Name: from.Name,
+	}, nil
+}
@@ -0,0 +1,125 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	f "github.com/underbek/datamapper/_test_data/mapper/recursive/from"
+	t "github.com/underbek/datamapper/_test_data/mapper/recursive/to"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertFAccountToTAccount convert f.Account by tag recursive to t.Account by tag recursive
+func ConvertFAccountToTAccount(from f.Account) t.Account {
+	return t.Account{
+		ID:     from.ID,
+		Amount: converts.ConvertDecimalToString(from.Amount),
+	}
+}
+
+// ConvertTAccountToFAccount convert t.Account by tag recursive to f.Account by tag recursive
+func ConvertTAccountToFAccount(from t.Account) (f.Account, error) {
+	fromAmount, err := converts.ConvertStringToDecimal(from.Amount)
+	if err != nil {
+		return f.Account{}, fmt.Errorf("convert Account.Amount -> Account.Amount failed: %w", err)
+	}
+
+	return f.Account{
+		ID:     from.ID,
+		Amount: fromAmount,
+	}, nil
+}
+
+// ConvertFUserToTUser convert f.User by tag recursive to t.User by tag recursive
+func ConvertFUserToTUser(from f.User) (t.User, error) {
+	if from.Account == nil {
+		return t.User{}, errors.New("cannot convert f.User.Account -> t.User.Account, field is nil")
+	}
+
+	return t.User{
+		ID:      converts.ConvertNumericToString(from.ID),
+		Account: ConvertFAccountToTAccount(*from.Account),
+	}, nil
+}
+
+// ConvertTUserToFUser convert t.User by tag recursive to f.User by tag recursive
+func ConvertTUserToFUser(from t.User) (f.User, error) {
+	fromID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return f.User{}, fmt.Errorf("convert User.ID -> User.ID failed: %w", err)
+	}
+
+	fromAccount, err := ConvertTAccountToFAccount(from.Account)
+	if err != nil {
+		return f.User{}, fmt.Errorf("convert User.Account -> User.Account failed: %w", err)
+	}
+
+	return f.User{
+		ID:      fromID,
+		Account: &fromAccount,
+	}, nil
+}
+
+// ConvertFOperationToTOperation convert f.Operation by tag recursive to t.Operation by tag recursive
+func ConvertFOperationToTOperation(from f.Operation) t.Operation {
+	return t.Operation{
+		ID:     converts.ConvertOrderedToOrdered[int64, uint64](from.ID),
+		Status: from.Status,
+	}
+}
+
+// ConvertTOperationToFOperation convert t.Operation by tag recursive to f.Operation by tag recursive
+func ConvertTOperationToFOperation(from t.Operation) f.Operation {
+	return f.Operation{
+		ID:     converts.ConvertOrderedToOrdered[uint64, int64](from.ID),
+		Status: from.Status,
+	}
+}
+
+// ConvertFOrderToTOrder convert f.Order by tag recursive to t.Order by tag recursive
+func ConvertFOrderToTOrder(from f.Order) (t.Order, error) {
+	fromUser, err := ConvertFUserToTUser(from.User)
+	if err != nil {
+		return t.Order{}, fmt.Errorf("convert Order.User -> Order.User failed: %w", err)
+	}
+
+	fromOperations := make([]*t.Operation, 0, len(from.Operations))
+	for _, item := range from.Operations {
+		res := ConvertFOperationToTOperation(item)
+
+		fromOperations = append(fromOperations, &res)
+	}
+
+	return t.Order{
+		ID:         converts.ConvertOrderedToOrdered[int64, int](from.ID),
+		User:       fromUser,
+		Operations: fromOperations,
+	}, nil
+}
+
+// ConvertTOrderToFOrder convert t.Order by tag recursive to f.Order by tag recursive
+func ConvertTOrderToFOrder(from t.Order) (f.Order, error) {
+	fromUser, err := ConvertTUserToFUser(from.User)
+	if err != nil {
+		return f.Order{}, fmt.Errorf("convert Order.User -> Order.User failed: %w", err)
+	}
+
+	fromOperations := make([]f.Operation, 0, len(from.Operations))
+	for _, item := range from.Operations {
+		if item == nil {
+			return f.Order{}, errors.New("cannot convert t.Order.Operations -> f.Order.Operations, field is nil")
+		}
+
+		fromOperations = append(fromOperations, ConvertTOperationToFOperation(*item))
+	}
+
+	return f.Order{
+		ID:         converts.ConvertOrderedToOrdered[int, int64](from.ID),
+		User:       fromUser,
+		Operations: fromOperations,
+	}, nil
+}
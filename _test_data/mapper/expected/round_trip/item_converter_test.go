@@ -0,0 +1,30 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+package mapper
+
+import (
+	"testing"
+
+	"github.com/underbek/datamapper/_test_data/mapper/round_trip/domain"
+)
+
+// FuzzConvertDomainItemToDtoItemRoundTrip checks that ConvertDtoItemToDomainItem restores fields converted by ConvertDomainItemToDtoItem
+func FuzzConvertDomainItemToDtoItemRoundTrip(f *testing.F) {
+	f.Add(string(""))
+	f.Add(string("datamapper"))
+
+	f.Fuzz(func(t *testing.T, fieldTitle string) {
+		from := domain.Item{
+			Title: fieldTitle,
+		}
+
+		to := ConvertDomainItemToDtoItem(from)
+
+		res := ConvertDtoItemToDomainItem(to)
+
+		if res.Title != from.Title {
+			t.Errorf("Title is not restored: got %v, want %v", res.Title, from.Title)
+		}
+	})
+}
@@ -0,0 +1,53 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+package mapper
+
+import (
+	"math"
+	"testing"
+
+	"github.com/underbek/datamapper/_test_data/mapper/round_trip/domain"
+)
+
+// FuzzConvertDomainProductToDtoProductRoundTrip checks that ConvertDtoProductToDomainProduct restores fields converted by ConvertDomainProductToDtoProduct
+func FuzzConvertDomainProductToDtoProductRoundTrip(f *testing.F) {
+	f.Add(int(0), string(""), float64(0), int(0))
+	f.Add(int(1), string("datamapper"), float64(1.5), int(1))
+
+	f.Fuzz(func(t *testing.T, fieldID int, fieldName string, fieldPrice float64, fieldCode int) {
+		if math.IsNaN(float64(fieldPrice)) {
+			t.Skip("NaN is not equal to itself")
+		}
+
+		from := domain.Product{
+			ID:    fieldID,
+			Name:  fieldName,
+			Price: fieldPrice,
+			Code:  fieldCode,
+		}
+
+		to := ConvertDomainProductToDtoProduct(from)
+
+		res, err := ConvertDtoProductToDomainProduct(to)
+		if err != nil {
+			t.Fatalf("inverse conversion error: %s", err)
+		}
+
+		if res.ID != from.ID {
+			t.Errorf("ID is not restored: got %v, want %v", res.ID, from.ID)
+		}
+
+		if res.Name != from.Name {
+			t.Errorf("Name is not restored: got %v, want %v", res.Name, from.Name)
+		}
+
+		if res.Price != from.Price {
+			t.Errorf("Price is not restored: got %v, want %v", res.Price, from.Price)
+		}
+
+		if res.Code != from.Code {
+			t.Errorf("Code is not restored: got %v, want %v", res.Code, from.Code)
+		}
+	})
+}
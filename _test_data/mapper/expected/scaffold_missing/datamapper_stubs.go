@@ -0,0 +1,36 @@
+// Conversion function stubs created by datamapper --scaffold-missing.
+// Implement stubs and keep them in the package of conversion functions.
+
+package stubs
+
+import "github.com/underbek/datamapper/_test_data/mapper/unconvertible/domain"
+
+// ConvertDomainMoneyToFloat64 converts domain.Money to float64
+func ConvertDomainMoneyToFloat64(from domain.Money) float64 {
+	panic("TODO")
+}
+
+// ConvertFloat64ToDomainMoney converts float64 to domain.Money
+func ConvertFloat64ToDomainMoney(from float64) domain.Money {
+	panic("TODO")
+}
+
+// ConvertDomainStatusToString converts domain.Status to string
+func ConvertDomainStatusToString(from domain.Status) string {
+	panic("TODO")
+}
+
+// ConvertDomainMoneyToString converts domain.Money to string
+func ConvertDomainMoneyToString(from domain.Money) string {
+	panic("TODO")
+}
+
+// ConvertStringToDomainStatus converts string to domain.Status
+func ConvertStringToDomainStatus(from string) domain.Status {
+	panic("TODO")
+}
+
+// ConvertStringToDomainMoney converts string to domain.Money
+func ConvertStringToDomainMoney(from string) domain.Money {
+	panic("TODO")
+}
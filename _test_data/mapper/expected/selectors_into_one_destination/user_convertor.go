@@ -0,0 +1,27 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"github.com/underbek/datamapper/_test_data/mapper/selectors/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/selectors/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainAccountToDtoAccountDTO convert domain.Account by tag map to dto.AccountDTO by tag map
+func ConvertDomainAccountToDtoAccountDTO(from domain.Account) dto.AccountDTO {
+	return dto.AccountDTO{
+		ID:     converts.ConvertNumericToString(from.ID),
+		Amount: converts.ConvertOrderedToOrdered[float64, float32](from.Amount),
+	}
+}
+
+// ConvertDomainUserToDtoUserDTO convert domain.User by tag map to dto.UserDTO by tag map
+func ConvertDomainUserToDtoUserDTO(from domain.User) dto.UserDTO {
+	return dto.UserDTO{
+		ID:   converts.ConvertNumericToString(from.ID),
+		Name: from.Name,
+	}
+}
@@ -0,0 +1,34 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/selectors/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/selectors/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainAccountToDtoAccountDTO convert domain.Account by tag map to dto.AccountDTO by tag map
+func ConvertDomainAccountToDtoAccountDTO(from domain.Account) dto.AccountDTO {
+	return dto.AccountDTO{
+		ID:     converts.ConvertNumericToString(from.ID),
+		Amount: converts.ConvertOrderedToOrdered[float64, float32](from.Amount),
+	}
+}
+
+// ConvertDtoAccountDTOToDomainAccount convert dto.AccountDTO by tag map to domain.Account by tag map
+func ConvertDtoAccountDTOToDomainAccount(from dto.AccountDTO) (domain.Account, error) {
+	fromID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domain.Account{}, fmt.Errorf("convert AccountDTO.ID -> Account.ID failed: %w", err)
+	}
+
+	return domain.Account{
+		ID:     fromID,
+		Amount: converts.ConvertOrderedToOrdered[float32, float64](from.Amount),
+	}, nil
+}
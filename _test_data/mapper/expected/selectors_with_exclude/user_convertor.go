@@ -0,0 +1,34 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/selectors/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/selectors/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainUserToDtoUserDTO convert domain.User by tag map to dto.UserDTO by tag map
+func ConvertDomainUserToDtoUserDTO(from domain.User) dto.UserDTO {
+	return dto.UserDTO{
+		ID:   converts.ConvertNumericToString(from.ID),
+		Name: from.Name,
+	}
+}
+
+// ConvertDtoUserDTOToDomainUser convert dto.UserDTO by tag map to domain.User by tag map
+func ConvertDtoUserDTOToDomainUser(from dto.UserDTO) (domain.User, error) {
+	fromID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("convert UserDTO.ID -> User.ID failed: %w", err)
+	}
+
+	return domain.User{
+		ID:   fromID,
+		Name: from.Name,
+	}, nil
+}
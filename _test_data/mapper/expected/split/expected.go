@@ -0,0 +1,48 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/split/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/split/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDtoRoleToDomainRole convert dto.Role by tag json to domain.Role by tag map
+func ConvertDtoRoleToDomainRole(from dto.Role) domain.Role {
+	return domain.Role{
+		Name: from.Name,
+	}
+}
+
+// ConvertDtoCreateUserRequestToDomainUserDomainAddress convert *dto.CreateUserRequest by tag json to domain.User by tag map, *domain.Address by tag addr
+func ConvertDtoCreateUserRequestToDomainUserDomainAddress(from *dto.CreateUserRequest) (domain.User, *domain.Address, error) {
+	if from == nil {
+		return domain.User{}, nil, errors.New("CreateUserRequest is nil")
+	}
+
+	fromDomainUserID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domain.User{}, nil, fmt.Errorf("convert CreateUserRequest.ID -> User.ID failed: %w", err)
+	}
+
+	fromDomainAddressID, err := converts.ConvertStringToSigned[int64](from.ID)
+	if err != nil {
+		return domain.User{}, nil, fmt.Errorf("convert CreateUserRequest.ID -> Address.UserID failed: %w", err)
+	}
+
+	return domain.User{
+		ID:   fromDomainUserID,
+		Name: from.Name,
+		Role: ConvertDtoRoleToDomainRole(from.Role),
+	}, &domain.Address{
+		UserID: fromDomainAddressID,
+		City:   from.City,
+		Street: from.Street,
+	}, nil
+}
@@ -0,0 +1,44 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/values/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/values/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainUserToDtoUser convert domain.User by tag map to dto.User by tag json
+func ConvertDomainUserToDtoUser(from domain.User) (dto.User, error) {
+	var defaultName string
+	if from.Name == nil {
+		defaultName = "anonymous"
+	} else {
+		defaultName = *from.Name
+	}
+
+	var defaultAge int
+	if from.Age == nil {
+		defaultAge = 18
+	} else {
+		fromAge, err := converts.ConvertStringToSigned[int](*from.Age)
+		if err != nil {
+			return dto.User{}, fmt.Errorf("convert User.Age -> User.Age failed: %w", err)
+		}
+
+		defaultAge = fromAge
+	}
+
+	return dto.User{
+		ID:      converts.ConvertNumericToString(from.ID),
+		Name:    defaultName,
+		Age:     defaultAge,
+		Version: 2,
+		Source:  "api",
+		Kind:    "admin",
+	}, nil
+}
@@ -0,0 +1,231 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/convertors"
+	db "github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/dao"
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/domain/user"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainOrderToDbOrderData convert *domain.Order by tag map to db.OrderData by tag db
+func ConvertDomainOrderToDbOrderData(from *domain.Order) (db.OrderData, error) {
+	if from == nil {
+		return db.OrderData{}, errors.New("Order is nil")
+	}
+
+	if from.OrderID == nil {
+		return db.OrderData{}, errors.New("cannot convert *domain.Order.OrderID -> db.OrderData.Order.ID, field is nil")
+	}
+
+	fromOrderID, err := converts.ConvertStringToSigned[int64](*from.OrderID)
+	if err != nil {
+		return db.OrderData{}, fmt.Errorf("convert Order.OrderID -> OrderData.Order.ID failed: %w", err)
+	}
+
+	fromAdditions := make([]db.Additional, 0, len(from.Additions))
+	for _, item := range from.Additions {
+		fromAdditions = append(fromAdditions, convertors.ConvertDomainAdditionalToDaoAdditional(item))
+	}
+
+	if from.User == nil {
+		return db.OrderData{}, errors.New("Order.User is nil")
+	}
+
+	fromUserID, err := converts.ConvertStringToSigned[int64](from.User.ID)
+	if err != nil {
+		return db.OrderData{}, fmt.Errorf("convert Order.User.ID -> OrderData.UserData.ID failed: %w", err)
+	}
+
+	if from.User.UserTimes == nil {
+		return db.OrderData{}, errors.New("Order.User.UserTimes is nil")
+	}
+
+	return db.OrderData{
+		Order: &db.Order{
+			ID:        fromOrderID,
+			UUID:      from.OrderUUID,
+			Additions: fromAdditions,
+		},
+		UserData: &db.User{
+			ID:        fromUserID,
+			CreatedAt: from.User.UserTimes.CreatedAt,
+		},
+		Urls: db.OrderUrls{
+			SiteUrl:     from.SiteUrl,
+			RedirectUrl: from.RedirectUrl,
+		},
+	}, nil
+}
+
+// ConvertDbOrderDataToDomainOrder convert db.OrderData by tag db to *domain.Order by tag map
+func ConvertDbOrderDataToDomainOrder(from db.OrderData) (*domain.Order, error) {
+	if from.Order == nil {
+		return nil, errors.New("OrderData.Order is nil")
+	}
+
+	fromOrderID := converts.ConvertNumericToString(from.Order.ID)
+
+	if from.UserData == nil {
+		return nil, errors.New("OrderData.UserData is nil")
+	}
+
+	fromOrderAdditions := make([]domain.Additional, 0, len(from.Order.Additions))
+	for _, item := range from.Order.Additions {
+		fromOrderAdditions = append(fromOrderAdditions, convertors.ConvertDaoAdditionalToDomainAdditional(item))
+	}
+
+	return &domain.Order{
+		OrderID:     &fromOrderID,
+		OrderUUID:   from.Order.UUID,
+		SiteUrl:     from.Urls.SiteUrl,
+		RedirectUrl: from.Urls.RedirectUrl,
+		Additions:   fromOrderAdditions,
+		User: &user.User{
+			ID: converts.ConvertNumericToString(from.UserData.ID),
+			UserTimes: &user.Times{
+				CreatedAt: from.UserData.CreatedAt,
+			},
+		},
+	}, nil
+}
+
+// ApplyDomainOrderToDbOrderData applies non-zero fields of *domain.Order by tag map to db.OrderData by tag db
+func ApplyDomainOrderToDbOrderData(from *domain.Order, to *db.OrderData) error {
+	if from == nil {
+		return nil
+	}
+
+	if to == nil {
+		return errors.New("db.OrderData is nil")
+	}
+
+	if from.OrderID != nil {
+		if to.Order == nil {
+			to.Order = &db.Order{}
+		}
+
+		fromOrderID, err := converts.ConvertStringToSigned[int64](*from.OrderID)
+		if err != nil {
+			return fmt.Errorf("convert Order.OrderID -> OrderData.Order.ID failed: %w", err)
+		}
+
+		to.Order.ID = fromOrderID
+	}
+
+	if from.OrderUUID != "" {
+		if to.Order == nil {
+			to.Order = &db.Order{}
+		}
+
+		to.Order.UUID = from.OrderUUID
+	}
+
+	if from.Additions != nil {
+		if to.Order == nil {
+			to.Order = &db.Order{}
+		}
+
+		fromAdditions := make([]db.Additional, 0, len(from.Additions))
+		for _, item := range from.Additions {
+			fromAdditions = append(fromAdditions, convertors.ConvertDomainAdditionalToDaoAdditional(item))
+		}
+
+		to.Order.Additions = fromAdditions
+	}
+
+	if from.User != nil && from.User.ID != "" {
+		if to.UserData == nil {
+			to.UserData = &db.User{}
+		}
+
+		fromUserID, err := converts.ConvertStringToSigned[int64](from.User.ID)
+		if err != nil {
+			return fmt.Errorf("convert Order.User.ID -> OrderData.UserData.ID failed: %w", err)
+		}
+
+		to.UserData.ID = fromUserID
+	}
+
+	if from.User != nil && from.User.UserTimes != nil && !reflect.ValueOf(from.User.UserTimes.CreatedAt).IsZero() {
+		if to.UserData == nil {
+			to.UserData = &db.User{}
+		}
+
+		to.UserData.CreatedAt = from.User.UserTimes.CreatedAt
+	}
+
+	if from.SiteUrl != "" {
+		to.Urls.SiteUrl = from.SiteUrl
+	}
+
+	if from.RedirectUrl != "" {
+		to.Urls.RedirectUrl = from.RedirectUrl
+	}
+
+	return nil
+}
+
+// ApplyDbOrderDataToDomainOrder applies non-zero fields of db.OrderData by tag db to domain.Order by tag map
+func ApplyDbOrderDataToDomainOrder(from db.OrderData, to *domain.Order) error {
+	if to == nil {
+		return errors.New("domain.Order is nil")
+	}
+
+	if from.Order != nil && from.Order.ID != 0 {
+		fromOrderID := converts.ConvertNumericToString(from.Order.ID)
+
+		to.OrderID = &fromOrderID
+	}
+
+	if from.Order != nil && from.Order.UUID != "" {
+		to.OrderUUID = from.Order.UUID
+	}
+
+	if from.UserData != nil && from.UserData.ID != 0 {
+		if to.User == nil {
+			to.User = &user.User{}
+		}
+
+		to.User.ID = converts.ConvertNumericToString(from.UserData.ID)
+	}
+
+	if from.UserData != nil && !reflect.ValueOf(from.UserData.CreatedAt).IsZero() {
+		if to.User == nil {
+			to.User = &user.User{}
+		}
+
+		if to.User.UserTimes == nil {
+			to.User.UserTimes = &user.Times{}
+		}
+
+		to.User.UserTimes.CreatedAt = from.UserData.CreatedAt
+	}
+
+	if from.Urls.SiteUrl != "" {
+		to.SiteUrl = from.Urls.SiteUrl
+	}
+
+	if from.Urls.RedirectUrl != "" {
+		to.RedirectUrl = from.Urls.RedirectUrl
+	}
+
+	if from.Order != nil && from.Order.Additions != nil {
+		fromOrderAdditions := make([]domain.Additional, 0, len(from.Order.Additions))
+		for _, item := range from.Order.Additions {
+			fromOrderAdditions = append(fromOrderAdditions, convertors.ConvertDaoAdditionalToDomainAdditional(item))
+		}
+
+		to.Additions = fromOrderAdditions
+	}
+
+	return nil
+}
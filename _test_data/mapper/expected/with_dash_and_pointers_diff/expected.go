@@ -0,0 +1,318 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/convertors"
+	db "github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/dao"
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/domain/user"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainOrderToDbOrderData convert *domain.Order by tag map to db.OrderData by tag db
+func ConvertDomainOrderToDbOrderData(from *domain.Order) (db.OrderData, error) {
+	if from == nil {
+		return db.OrderData{}, errors.New("Order is nil")
+	}
+
+	if from.OrderID == nil {
+		return db.OrderData{}, errors.New("cannot convert *domain.Order.OrderID -> db.OrderData.Order.ID, field is nil")
+	}
+
+	fromOrderID, err := converts.ConvertStringToSigned[int64](*from.OrderID)
+	if err != nil {
+		return db.OrderData{}, fmt.Errorf("convert Order.OrderID -> OrderData.Order.ID failed: %w", err)
+	}
+
+	fromAdditions := make([]db.Additional, 0, len(from.Additions))
+	for _, item := range from.Additions {
+		fromAdditions = append(fromAdditions, convertors.ConvertDomainAdditionalToDaoAdditional(item))
+	}
+
+	if from.User == nil {
+		return db.OrderData{}, errors.New("Order.User is nil")
+	}
+
+	fromUserID, err := converts.ConvertStringToSigned[int64](from.User.ID)
+	if err != nil {
+		return db.OrderData{}, fmt.Errorf("convert Order.User.ID -> OrderData.UserData.ID failed: %w", err)
+	}
+
+	if from.User.UserTimes == nil {
+		return db.OrderData{}, errors.New("Order.User.UserTimes is nil")
+	}
+
+	return db.OrderData{
+		Order: &db.Order{
+			ID:        fromOrderID,
+			UUID:      from.OrderUUID,
+			Additions: fromAdditions,
+		},
+		UserData: &db.User{
+			ID:        fromUserID,
+			CreatedAt: from.User.UserTimes.CreatedAt,
+		},
+		Urls: db.OrderUrls{
+			SiteUrl:     from.SiteUrl,
+			RedirectUrl: from.RedirectUrl,
+		},
+	}, nil
+}
+
+// ConvertDbOrderDataToDomainOrder convert db.OrderData by tag db to *domain.Order by tag map
+func ConvertDbOrderDataToDomainOrder(from db.OrderData) (*domain.Order, error) {
+	if from.Order == nil {
+		return nil, errors.New("OrderData.Order is nil")
+	}
+
+	fromOrderID := converts.ConvertNumericToString(from.Order.ID)
+
+	if from.UserData == nil {
+		return nil, errors.New("OrderData.UserData is nil")
+	}
+
+	fromOrderAdditions := make([]domain.Additional, 0, len(from.Order.Additions))
+	for _, item := range from.Order.Additions {
+		fromOrderAdditions = append(fromOrderAdditions, convertors.ConvertDaoAdditionalToDomainAdditional(item))
+	}
+
+	return &domain.Order{
+		OrderID:     &fromOrderID,
+		OrderUUID:   from.Order.UUID,
+		SiteUrl:     from.Urls.SiteUrl,
+		RedirectUrl: from.Urls.RedirectUrl,
+		Additions:   fromOrderAdditions,
+		User: &user.User{
+			ID: converts.ConvertNumericToString(from.UserData.ID),
+			UserTimes: &user.Times{
+				CreatedAt: from.UserData.CreatedAt,
+			},
+		},
+	}, nil
+}
+
+// DiffDomainOrderDbOrderData returns mismatches of fields of db.OrderData and converted fields of *domain.Order
+func DiffDomainOrderDbOrderData(from *domain.Order, to db.OrderData) []string {
+	if from == nil {
+		return []string{"from is nil"}
+	}
+
+	var diff []string
+
+	if to.Order == nil {
+		diff = append(diff, "OrderData.Order.ID: embedded struct is nil")
+	} else {
+		var expectedOrderID int64
+		if _, err := func() (db.OrderData, error) {
+			if from.OrderID == nil {
+				return db.OrderData{}, errors.New("cannot convert *domain.Order.OrderID -> db.OrderData.Order.ID, field is nil")
+			}
+
+			fromOrderID, err := converts.ConvertStringToSigned[int64](*from.OrderID)
+			if err != nil {
+				return db.OrderData{}, fmt.Errorf("convert Order.OrderID -> OrderData.Order.ID failed: %w", err)
+			}
+
+			expectedOrderID = fromOrderID
+			return db.OrderData{}, nil
+		}(); err != nil {
+			diff = append(diff, fmt.Sprintf("OrderData.Order.ID: %s", err))
+		} else if !reflect.DeepEqual(expectedOrderID, to.Order.ID) {
+			diff = append(diff, fmt.Sprintf("OrderData.Order.ID: expected %v, got %v", expectedOrderID, to.Order.ID))
+		}
+	}
+
+	if to.Order == nil {
+		diff = append(diff, "OrderData.Order.UUID: embedded struct is nil")
+	} else {
+		if expected := from.OrderUUID; !reflect.DeepEqual(expected, to.Order.UUID) {
+			diff = append(diff, fmt.Sprintf("OrderData.Order.UUID: expected %v, got %v", expected, to.Order.UUID))
+		}
+	}
+
+	if to.Order == nil {
+		diff = append(diff, "OrderData.Order.Additions: embedded struct is nil")
+	} else {
+		var expectedOrderAdditions []db.Additional
+		if _, err := func() (db.OrderData, error) {
+			fromAdditions := make([]db.Additional, 0, len(from.Additions))
+			for _, item := range from.Additions {
+				fromAdditions = append(fromAdditions, convertors.ConvertDomainAdditionalToDaoAdditional(item))
+			}
+
+			expectedOrderAdditions = fromAdditions
+			return db.OrderData{}, nil
+		}(); err != nil {
+			diff = append(diff, fmt.Sprintf("OrderData.Order.Additions: %s", err))
+		} else if !reflect.DeepEqual(expectedOrderAdditions, to.Order.Additions) {
+			diff = append(diff, fmt.Sprintf("OrderData.Order.Additions: expected %v, got %v", expectedOrderAdditions, to.Order.Additions))
+		}
+	}
+
+	if to.UserData == nil {
+		diff = append(diff, "OrderData.UserData.ID: embedded struct is nil")
+	} else {
+		var expectedUserDataID int64
+		if _, err := func() (db.OrderData, error) {
+			if from.User == nil {
+				return db.OrderData{}, errors.New("Order.User is nil")
+			}
+
+			fromUserID, err := converts.ConvertStringToSigned[int64](from.User.ID)
+			if err != nil {
+				return db.OrderData{}, fmt.Errorf("convert Order.User.ID -> OrderData.UserData.ID failed: %w", err)
+			}
+
+			expectedUserDataID = fromUserID
+			return db.OrderData{}, nil
+		}(); err != nil {
+			diff = append(diff, fmt.Sprintf("OrderData.UserData.ID: %s", err))
+		} else if !reflect.DeepEqual(expectedUserDataID, to.UserData.ID) {
+			diff = append(diff, fmt.Sprintf("OrderData.UserData.ID: expected %v, got %v", expectedUserDataID, to.UserData.ID))
+		}
+	}
+
+	if to.UserData == nil {
+		diff = append(diff, "OrderData.UserData.CreatedAt: embedded struct is nil")
+	} else {
+		var expectedUserDataCreatedAt time.Time
+		if _, err := func() (db.OrderData, error) {
+			if from.User == nil {
+				return db.OrderData{}, errors.New("Order.User is nil")
+			}
+
+			if from.User.UserTimes == nil {
+				return db.OrderData{}, errors.New("Order.User.UserTimes is nil")
+			}
+
+			expectedUserDataCreatedAt = from.User.UserTimes.CreatedAt
+			return db.OrderData{}, nil
+		}(); err != nil {
+			diff = append(diff, fmt.Sprintf("OrderData.UserData.CreatedAt: %s", err))
+		} else if !reflect.DeepEqual(expectedUserDataCreatedAt, to.UserData.CreatedAt) {
+			diff = append(diff, fmt.Sprintf("OrderData.UserData.CreatedAt: expected %v, got %v", expectedUserDataCreatedAt, to.UserData.CreatedAt))
+		}
+	}
+
+	if expected := from.SiteUrl; !reflect.DeepEqual(expected, to.Urls.SiteUrl) {
+		diff = append(diff, fmt.Sprintf("OrderData.Urls.SiteUrl: expected %v, got %v", expected, to.Urls.SiteUrl))
+	}
+
+	if expected := from.RedirectUrl; !reflect.DeepEqual(expected, to.Urls.RedirectUrl) {
+		diff = append(diff, fmt.Sprintf("OrderData.Urls.RedirectUrl: expected %v, got %v", expected, to.Urls.RedirectUrl))
+	}
+
+	return diff
+}
+
+// DiffDbOrderDataDomainOrder returns mismatches of fields of *domain.Order and converted fields of db.OrderData
+func DiffDbOrderDataDomainOrder(from db.OrderData, to *domain.Order) []string {
+	if to == nil {
+		return []string{"to is nil"}
+	}
+
+	var diff []string
+
+	var expectedOrderID *string
+	if _, err := func() (*domain.Order, error) {
+		if from.Order == nil {
+			return nil, errors.New("OrderData.Order is nil")
+		}
+
+		fromOrderID := converts.ConvertNumericToString(from.Order.ID)
+
+		expectedOrderID = &fromOrderID
+		return nil, nil
+	}(); err != nil {
+		diff = append(diff, fmt.Sprintf("Order.OrderID: %s", err))
+	} else if !reflect.DeepEqual(expectedOrderID, to.OrderID) {
+		diff = append(diff, fmt.Sprintf("Order.OrderID: expected %v, got %v", expectedOrderID, to.OrderID))
+	}
+
+	var expectedOrderUUID string
+	if _, err := func() (*domain.Order, error) {
+		if from.Order == nil {
+			return nil, errors.New("OrderData.Order is nil")
+		}
+
+		expectedOrderUUID = from.Order.UUID
+		return nil, nil
+	}(); err != nil {
+		diff = append(diff, fmt.Sprintf("Order.OrderUUID: %s", err))
+	} else if !reflect.DeepEqual(expectedOrderUUID, to.OrderUUID) {
+		diff = append(diff, fmt.Sprintf("Order.OrderUUID: expected %v, got %v", expectedOrderUUID, to.OrderUUID))
+	}
+
+	if to.User == nil {
+		diff = append(diff, "Order.User.ID: embedded struct is nil")
+	} else {
+		var expectedUserID string
+		if _, err := func() (*domain.Order, error) {
+			if from.UserData == nil {
+				return nil, errors.New("OrderData.UserData is nil")
+			}
+
+			expectedUserID = converts.ConvertNumericToString(from.UserData.ID)
+			return nil, nil
+		}(); err != nil {
+			diff = append(diff, fmt.Sprintf("Order.User.ID: %s", err))
+		} else if !reflect.DeepEqual(expectedUserID, to.User.ID) {
+			diff = append(diff, fmt.Sprintf("Order.User.ID: expected %v, got %v", expectedUserID, to.User.ID))
+		}
+	}
+
+	if to.User == nil || to.User.UserTimes == nil {
+		diff = append(diff, "Order.User.UserTimes.CreatedAt: embedded struct is nil")
+	} else {
+		var expectedUserUserTimesCreatedAt time.Time
+		if _, err := func() (*domain.Order, error) {
+			if from.UserData == nil {
+				return nil, errors.New("OrderData.UserData is nil")
+			}
+
+			expectedUserUserTimesCreatedAt = from.UserData.CreatedAt
+			return nil, nil
+		}(); err != nil {
+			diff = append(diff, fmt.Sprintf("Order.User.UserTimes.CreatedAt: %s", err))
+		} else if !reflect.DeepEqual(expectedUserUserTimesCreatedAt, to.User.UserTimes.CreatedAt) {
+			diff = append(diff, fmt.Sprintf("Order.User.UserTimes.CreatedAt: expected %v, got %v", expectedUserUserTimesCreatedAt, to.User.UserTimes.CreatedAt))
+		}
+	}
+
+	if expected := from.Urls.SiteUrl; !reflect.DeepEqual(expected, to.SiteUrl) {
+		diff = append(diff, fmt.Sprintf("Order.SiteUrl: expected %v, got %v", expected, to.SiteUrl))
+	}
+
+	if expected := from.Urls.RedirectUrl; !reflect.DeepEqual(expected, to.RedirectUrl) {
+		diff = append(diff, fmt.Sprintf("Order.RedirectUrl: expected %v, got %v", expected, to.RedirectUrl))
+	}
+
+	var expectedAdditions []domain.Additional
+	if _, err := func() (*domain.Order, error) {
+		if from.Order == nil {
+			return nil, errors.New("OrderData.Order is nil")
+		}
+
+		fromOrderAdditions := make([]domain.Additional, 0, len(from.Order.Additions))
+		for _, item := range from.Order.Additions {
+			fromOrderAdditions = append(fromOrderAdditions, convertors.ConvertDaoAdditionalToDomainAdditional(item))
+		}
+
+		expectedAdditions = fromOrderAdditions
+		return nil, nil
+	}(); err != nil {
+		diff = append(diff, fmt.Sprintf("Order.Additions: %s", err))
+	} else if !reflect.DeepEqual(expectedAdditions, to.Additions) {
+		diff = append(diff, fmt.Sprintf("Order.Additions: expected %v, got %v", expectedAdditions, to.Additions))
+	}
+
+	return diff
+}
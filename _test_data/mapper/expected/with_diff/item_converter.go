@@ -0,0 +1,49 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/underbek/datamapper/_test_data/mapper/round_trip/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/round_trip/dto"
+)
+
+// ConvertDomainItemToDtoItem convert domain.Item by tag map to dto.Item by tag map
+func ConvertDomainItemToDtoItem(from domain.Item) dto.Item {
+	return dto.Item{
+		Title: from.Title,
+	}
+}
+
+// ConvertDtoItemToDomainItem convert dto.Item by tag map to domain.Item by tag map
+func ConvertDtoItemToDomainItem(from dto.Item) domain.Item {
+	return domain.Item{
+		Title: from.Title,
+	}
+}
+
+// DiffDomainItemDtoItem returns mismatches of fields of dto.Item and converted fields of domain.Item
+func DiffDomainItemDtoItem(from domain.Item, to dto.Item) []string {
+	var diff []string
+
+	if expected := from.Title; !reflect.DeepEqual(expected, to.Title) {
+		diff = append(diff, fmt.Sprintf("Item.Title: expected %v, got %v", expected, to.Title))
+	}
+
+	return diff
+}
+
+// DiffDtoItemDomainItem returns mismatches of fields of domain.Item and converted fields of dto.Item
+func DiffDtoItemDomainItem(from dto.Item, to domain.Item) []string {
+	var diff []string
+
+	if expected := from.Title; !reflect.DeepEqual(expected, to.Title) {
+		diff = append(diff, fmt.Sprintf("Item.Title: expected %v, got %v", expected, to.Title))
+	}
+
+	return diff
+}
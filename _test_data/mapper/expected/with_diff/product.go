@@ -0,0 +1,130 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/underbek/datamapper/_test_data/mapper/round_trip/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/round_trip/dto"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainProductToDtoProduct convert domain.Product by tag map to dto.Product by tag map
+func ConvertDomainProductToDtoProduct(from domain.Product) dto.Product {
+	return dto.Product{
+		ID:     from.ID,
+		Name:   from.Name,
+		Price:  from.Price,
+		Count:  converts.ConvertOrderedToOrdered[int64, int32](from.Count),
+		Code:   converts.ConvertNumericToString(from.Code),
+		Amount: converts.ConvertDecimalToString(from.Amount),
+		Tags:   from.Tags,
+		Item:   ConvertDomainItemToDtoItem(from.Item),
+	}
+}
+
+// ConvertDtoProductToDomainProduct convert dto.Product by tag map to domain.Product by tag map
+func ConvertDtoProductToDomainProduct(from dto.Product) (domain.Product, error) {
+	fromCode, err := converts.ConvertStringToSigned[int](from.Code)
+	if err != nil {
+		return domain.Product{}, fmt.Errorf("convert Product.Code -> Product.Code failed: %w", err)
+	}
+
+	fromAmount, err := converts.ConvertStringToDecimal(from.Amount)
+	if err != nil {
+		return domain.Product{}, fmt.Errorf("convert Product.Amount -> Product.Amount failed: %w", err)
+	}
+
+	return domain.Product{
+		ID:     from.ID,
+		Name:   from.Name,
+		Price:  from.Price,
+		Count:  converts.ConvertOrderedToOrdered[int32, int64](from.Count),
+		Code:   fromCode,
+		Amount: fromAmount,
+		Tags:   from.Tags,
+		Item:   ConvertDtoItemToDomainItem(from.Item),
+	}, nil
+}
+
+// DiffDomainProductDtoProduct returns mismatches of fields of dto.Product and converted fields of domain.Product
+func DiffDomainProductDtoProduct(from domain.Product, to dto.Product) []string {
+	var diff []string
+
+	if expected := from.ID; !reflect.DeepEqual(expected, to.ID) {
+		diff = append(diff, fmt.Sprintf("Product.ID: expected %v, got %v", expected, to.ID))
+	}
+
+	if expected := from.Name; !reflect.DeepEqual(expected, to.Name) {
+		diff = append(diff, fmt.Sprintf("Product.Name: expected %v, got %v", expected, to.Name))
+	}
+
+	if expected := from.Price; !reflect.DeepEqual(expected, to.Price) {
+		diff = append(diff, fmt.Sprintf("Product.Price: expected %v, got %v", expected, to.Price))
+	}
+
+	if expected := converts.ConvertOrderedToOrdered[int64, int32](from.Count); !reflect.DeepEqual(expected, to.Count) {
+		diff = append(diff, fmt.Sprintf("Product.Count: expected %v, got %v", expected, to.Count))
+	}
+
+	if expected := converts.ConvertNumericToString(from.Code); !reflect.DeepEqual(expected, to.Code) {
+		diff = append(diff, fmt.Sprintf("Product.Code: expected %v, got %v", expected, to.Code))
+	}
+
+	if expected := converts.ConvertDecimalToString(from.Amount); !reflect.DeepEqual(expected, to.Amount) {
+		diff = append(diff, fmt.Sprintf("Product.Amount: expected %v, got %v", expected, to.Amount))
+	}
+
+	if expected := from.Tags; !reflect.DeepEqual(expected, to.Tags) {
+		diff = append(diff, fmt.Sprintf("Product.Tags: expected %v, got %v", expected, to.Tags))
+	}
+
+	if expected := ConvertDomainItemToDtoItem(from.Item); !reflect.DeepEqual(expected, to.Item) {
+		diff = append(diff, fmt.Sprintf("Product.Item: expected %v, got %v", expected, to.Item))
+	}
+
+	return diff
+}
+
+// DiffDtoProductDomainProduct returns mismatches of fields of domain.Product and converted fields of dto.Product
+func DiffDtoProductDomainProduct(from dto.Product, to domain.Product) []string {
+	var diff []string
+
+	if expected := from.ID; !reflect.DeepEqual(expected, to.ID) {
+		diff = append(diff, fmt.Sprintf("Product.ID: expected %v, got %v", expected, to.ID))
+	}
+
+	if expected := from.Name; !reflect.DeepEqual(expected, to.Name) {
+		diff = append(diff, fmt.Sprintf("Product.Name: expected %v, got %v", expected, to.Name))
+	}
+
+	if expected := from.Price; !reflect.DeepEqual(expected, to.Price) {
+		diff = append(diff, fmt.Sprintf("Product.Price: expected %v, got %v", expected, to.Price))
+	}
+
+	if expected := converts.ConvertOrderedToOrdered[int32, int64](from.Count); !reflect.DeepEqual(expected, to.Count) {
+		diff = append(diff, fmt.Sprintf("Product.Count: expected %v, got %v", expected, to.Count))
+	}
+
+	if actual := converts.ConvertNumericToString(to.Code); !reflect.DeepEqual(from.Code, actual) {
+		diff = append(diff, fmt.Sprintf("Product.Code: expected %v, got %v", from.Code, actual))
+	}
+
+	if actual := converts.ConvertDecimalToString(to.Amount); !reflect.DeepEqual(from.Amount, actual) {
+		diff = append(diff, fmt.Sprintf("Product.Amount: expected %v, got %v", from.Amount, actual))
+	}
+
+	if expected := from.Tags; !reflect.DeepEqual(expected, to.Tags) {
+		diff = append(diff, fmt.Sprintf("Product.Tags: expected %v, got %v", expected, to.Tags))
+	}
+
+	if expected := ConvertDtoItemToDomainItem(from.Item); !reflect.DeepEqual(expected, to.Item) {
+		diff = append(diff, fmt.Sprintf("Product.Item: expected %v, got %v", expected, to.Item))
+	}
+
+	return diff
+}
@@ -7,10 +7,41 @@ package mapper
 import (
 	"fmt"
 
+	"github.com/underbek/datamapper/_test_data/mapper/convertors"
 	"github.com/underbek/datamapper/_test_data/mapper/domain"
 	"github.com/underbek/datamapper/_test_data/mapper/transport"
+	"github.com/underbek/datamapper/converts"
 )
 
+// ConvertTransportUserToDomainUser convert *transport.User by tag map to *domain.User by tag map
+func ConvertTransportUserToDomainUser(from *transport.User) (*domain.User, error) {
+	if from == nil {
+		return nil, nil
+	}
+
+	fromAge, err := converts.ConvertStringToDecimal(from.Age)
+	if err != nil {
+		return nil, fmt.Errorf("convert User.Age -> User.Age failed: %w", err)
+	}
+
+	var fromChildCount *int
+	if from.ChildCount != nil {
+		res, err := converts.ConvertStringToSigned[int](*from.ChildCount)
+		if err != nil {
+			return nil, fmt.Errorf("convert User.ChildCount -> User.ChildCount failed: %w", err)
+		}
+
+		fromChildCount = &res
+	}
+
+	return &domain.User{
+		ID:         convertors.CustomUUIDToInteger[int](from.UUID),
+		Name:       from.Name,
+		Age:        fromAge,
+		ChildCount: fromChildCount,
+	}, nil
+}
+
 // ConvertTransportOrderToDomainOrder convert transport.Order by tag map to domain.Order by tag map
 func ConvertTransportOrderToDomainOrder(from transport.Order) (domain.Order, error) {
 	fromUser, err := ConvertTransportUserToDomainUser(from.User)
@@ -0,0 +1,232 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+// Package mapper is a generated datamapper package.
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/convertors"
+	db "github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/dao"
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/domain"
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/domain/user"
+	"github.com/underbek/datamapper/converts"
+)
+
+// ConvertDomainOrderToDbOrderData convert *domain.Order by tag map to db.OrderData by tag db
+func ConvertDomainOrderToDbOrderData(from *domain.Order) (db.OrderData, error) {
+	if from == nil {
+		return db.OrderData{}, errors.New("Order is nil")
+	}
+
+	if from.OrderID == nil {
+		return db.OrderData{}, errors.New("cannot convert *domain.Order.OrderID -> db.OrderData.Order.ID, field is nil")
+	}
+
+	fromOrderID, err := converts.ConvertStringToSigned[int64](*from.OrderID)
+	if err != nil {
+		return db.OrderData{}, fmt.Errorf("convert Order.OrderID -> OrderData.Order.ID failed: %w", err)
+	}
+
+	fromAdditions := make([]db.Additional, 0, len(from.Additions))
+	for _, item := range from.Additions {
+		fromAdditions = append(fromAdditions, convertors.ConvertDomainAdditionalToDaoAdditional(item))
+	}
+
+	if from.User == nil {
+		return db.OrderData{}, errors.New("Order.User is nil")
+	}
+
+	fromUserID, err := converts.ConvertStringToSigned[int64](from.User.ID)
+	if err != nil {
+		return db.OrderData{}, fmt.Errorf("convert Order.User.ID -> OrderData.UserData.ID failed: %w", err)
+	}
+
+	if from.User.UserTimes == nil {
+		return db.OrderData{}, errors.New("Order.User.UserTimes is nil")
+	}
+
+	return db.OrderData{
+		Order: &db.Order{
+			ID:        fromOrderID,
+			UUID:      from.OrderUUID,
+			Additions: fromAdditions,
+		},
+		UserData: &db.User{
+			ID:        fromUserID,
+			CreatedAt: from.User.UserTimes.CreatedAt,
+		},
+		Urls: db.OrderUrls{
+			SiteUrl:     from.SiteUrl,
+			RedirectUrl: from.RedirectUrl,
+		},
+	}, nil
+}
+
+// ConvertDbOrderDataToDomainOrder convert db.OrderData by tag db to *domain.Order by tag map
+func ConvertDbOrderDataToDomainOrder(from db.OrderData) (*domain.Order, error) {
+	if from.Order == nil {
+		return nil, errors.New("OrderData.Order is nil")
+	}
+
+	fromOrderID := converts.ConvertNumericToString(from.Order.ID)
+
+	if from.UserData == nil {
+		return nil, errors.New("OrderData.UserData is nil")
+	}
+
+	fromOrderAdditions := make([]domain.Additional, 0, len(from.Order.Additions))
+	for _, item := range from.Order.Additions {
+		fromOrderAdditions = append(fromOrderAdditions, convertors.ConvertDaoAdditionalToDomainAdditional(item))
+	}
+
+	return &domain.Order{
+		OrderID:     &fromOrderID,
+		OrderUUID:   from.Order.UUID,
+		SiteUrl:     from.Urls.SiteUrl,
+		RedirectUrl: from.Urls.RedirectUrl,
+		Additions:   fromOrderAdditions,
+		User: &user.User{
+			ID: converts.ConvertNumericToString(from.UserData.ID),
+			UserTimes: &user.Times{
+				CreatedAt: from.UserData.CreatedAt,
+			},
+		},
+	}, nil
+}
+
+// ConvertDomainOrderIntoDbOrderData convert domain.Order by tag map into existing db.OrderData by tag db
+func ConvertDomainOrderIntoDbOrderData(from *domain.Order, to *db.OrderData) error {
+	if from == nil {
+		return errors.New("domain.Order is nil")
+	}
+
+	if to == nil {
+		return errors.New("db.OrderData is nil")
+	}
+
+	if from.OrderID == nil {
+		return errors.New("cannot convert *domain.Order.OrderID -> db.OrderData.Order.ID, field is nil")
+	}
+
+	fromOrderID, err := converts.ConvertStringToSigned[int64](*from.OrderID)
+	if err != nil {
+		return fmt.Errorf("convert Order.OrderID -> OrderData.Order.ID failed: %w", err)
+	}
+
+	fromAdditions := make([]db.Additional, 0, len(from.Additions))
+	for _, item := range from.Additions {
+		fromAdditions = append(fromAdditions, convertors.ConvertDomainAdditionalToDaoAdditional(item))
+	}
+
+	if from.User == nil {
+		return errors.New("Order.User is nil")
+	}
+
+	fromUserID, err := converts.ConvertStringToSigned[int64](from.User.ID)
+	if err != nil {
+		return fmt.Errorf("convert Order.User.ID -> OrderData.UserData.ID failed: %w", err)
+	}
+
+	if from.User.UserTimes == nil {
+		return errors.New("Order.User.UserTimes is nil")
+	}
+
+	if to.Order == nil {
+		to.Order = &db.Order{}
+	}
+
+	if to.UserData == nil {
+		to.UserData = &db.User{}
+	}
+
+	to.Order.ID = fromOrderID
+	to.Order.UUID = from.OrderUUID
+	to.Order.Additions = fromAdditions
+	to.UserData.ID = fromUserID
+	to.UserData.CreatedAt = from.User.UserTimes.CreatedAt
+	to.Urls.SiteUrl = from.SiteUrl
+	to.Urls.RedirectUrl = from.RedirectUrl
+
+	return nil
+}
+
+// ConvertDbOrderDataIntoDomainOrder convert db.OrderData by tag db into existing domain.Order by tag map
+func ConvertDbOrderDataIntoDomainOrder(from *db.OrderData, to *domain.Order) error {
+	if from == nil {
+		return errors.New("db.OrderData is nil")
+	}
+
+	if to == nil {
+		return errors.New("domain.Order is nil")
+	}
+
+	if from.Order == nil {
+		return errors.New("OrderData.Order is nil")
+	}
+
+	fromOrderID := converts.ConvertNumericToString(from.Order.ID)
+
+	if from.UserData == nil {
+		return errors.New("OrderData.UserData is nil")
+	}
+
+	fromOrderAdditions := make([]domain.Additional, 0, len(from.Order.Additions))
+	for _, item := range from.Order.Additions {
+		fromOrderAdditions = append(fromOrderAdditions, convertors.ConvertDaoAdditionalToDomainAdditional(item))
+	}
+
+	if to.User == nil {
+		to.User = &user.User{}
+	}
+
+	if to.User.UserTimes == nil {
+		to.User.UserTimes = &user.Times{}
+	}
+
+	to.OrderID = &fromOrderID
+	to.OrderUUID = from.Order.UUID
+	to.User.ID = converts.ConvertNumericToString(from.UserData.ID)
+	to.User.UserTimes.CreatedAt = from.UserData.CreatedAt
+	to.SiteUrl = from.Urls.SiteUrl
+	to.RedirectUrl = from.Urls.RedirectUrl
+	to.Additions = fromOrderAdditions
+
+	return nil
+}
+
+// ConvertDomainOrderSliceIntoDbOrderDataSlice convert []domain.Order into toSlice reusing its capacity.
+// Not mapped fields of reused items keep previous values
+func ConvertDomainOrderSliceIntoDbOrderDataSlice(fromSlice []domain.Order, toSlice []db.OrderData) ([]db.OrderData, error) {
+	if cap(toSlice) < len(fromSlice) {
+		toSlice = make([]db.OrderData, len(fromSlice))
+	}
+
+	toSlice = toSlice[:len(fromSlice)]
+	for i := range fromSlice {
+		if err := ConvertDomainOrderIntoDbOrderData(&fromSlice[i], &toSlice[i]); err != nil {
+			return nil, fmt.Errorf("convert []domain.Order into []db.OrderData failed: %w", err)
+		}
+	}
+
+	return toSlice, nil
+}
+
+// ConvertDbOrderDataSliceIntoDomainOrderSlice convert []db.OrderData into toSlice reusing its capacity.
+// Not mapped fields of reused items keep previous values
+func ConvertDbOrderDataSliceIntoDomainOrderSlice(fromSlice []db.OrderData, toSlice []domain.Order) ([]domain.Order, error) {
+	if cap(toSlice) < len(fromSlice) {
+		toSlice = make([]domain.Order, len(fromSlice))
+	}
+
+	toSlice = toSlice[:len(fromSlice)]
+	for i := range fromSlice {
+		if err := ConvertDbOrderDataIntoDomainOrder(&fromSlice[i], &toSlice[i]); err != nil {
+			return nil, fmt.Errorf("convert []db.OrderData into []domain.Order failed: %w", err)
+		}
+	}
+
+	return toSlice, nil
+}
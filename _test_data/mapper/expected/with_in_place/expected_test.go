@@ -0,0 +1,81 @@
+// Code generated by datamapper.
+// https://github.com/underbek/datamapper
+
+package mapper
+
+import (
+	"testing"
+
+	db "github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/dao"
+	"github.com/underbek/datamapper/_test_data/mapper/with_dash_and_pointers/domain"
+)
+
+// BenchmarkConvertDomainOrderIntoDbOrderData compares ConvertDomainOrderToDbOrderData with ConvertDomainOrderIntoDbOrderData by slice of 1000 models
+func BenchmarkConvertDomainOrderIntoDbOrderData(b *testing.B) {
+	fromSlice := make([]domain.Order, 1000)
+	if err := ConvertDomainOrderIntoDbOrderData(&fromSlice[0], new(db.OrderData)); err != nil {
+		b.Skipf("zero model cannot be converted: %s", err)
+	}
+
+	b.Run("convertor", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			toSlice := make([]db.OrderData, 0, len(fromSlice))
+			for j := range fromSlice {
+				to, err := ConvertDomainOrderToDbOrderData(&fromSlice[j])
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				toSlice = append(toSlice, to)
+			}
+		}
+	})
+
+	b.Run("in-place", func(b *testing.B) {
+		b.ReportAllocs()
+		var toSlice []db.OrderData
+		for i := 0; i < b.N; i++ {
+			var err error
+			toSlice, err = ConvertDomainOrderSliceIntoDbOrderDataSlice(fromSlice, toSlice)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// BenchmarkConvertDbOrderDataIntoDomainOrder compares ConvertDbOrderDataToDomainOrder with ConvertDbOrderDataIntoDomainOrder by slice of 1000 models
+func BenchmarkConvertDbOrderDataIntoDomainOrder(b *testing.B) {
+	fromSlice := make([]db.OrderData, 1000)
+	if err := ConvertDbOrderDataIntoDomainOrder(&fromSlice[0], new(domain.Order)); err != nil {
+		b.Skipf("zero model cannot be converted: %s", err)
+	}
+
+	b.Run("convertor", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			toSlice := make([]*domain.Order, 0, len(fromSlice))
+			for j := range fromSlice {
+				to, err := ConvertDbOrderDataToDomainOrder(fromSlice[j])
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				toSlice = append(toSlice, to)
+			}
+		}
+	})
+
+	b.Run("in-place", func(b *testing.B) {
+		b.ReportAllocs()
+		var toSlice []domain.Order
+		for i := 0; i < b.N; i++ {
+			var err error
+			toSlice, err = ConvertDbOrderDataSliceIntoDomainOrderSlice(fromSlice, toSlice)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
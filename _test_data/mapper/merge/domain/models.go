@@ -0,0 +1,21 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+type Address struct {
+	City   string `map:"city"`
+	Street string `map:"street"`
+}
+
+type User struct {
+	ID      string  `map:"id"`
+	Name    string  `map:"name"`
+	Address Address `map:"address"`
+}
+
+type Account struct {
+	ID      string          `profile:"account_id"`
+	UserID  string          `profile:"id"`
+	Name    string          `profile:"name"`
+	Balance decimal.Decimal `profile:"balance"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type Address struct {
+	City   string `json:"city"`
+	Street string `json:"street"`
+}
+
+type Profile struct {
+	ID        int64   `json:"id"`
+	Name      string  `json:"name"`
+	AccountID string  `json:"account_id"`
+	Balance   string  `json:"balance"`
+	Address   Address `json:"address"`
+}
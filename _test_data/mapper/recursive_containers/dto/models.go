@@ -0,0 +1,16 @@
+package dto
+
+type Basket struct {
+	ID       int64            `map:"id"`
+	Items    []*Item          `map:"items"`
+	Pinned   [2]Item          `map:"pinned"`
+	ByCode   map[string]*Item `map:"by_code"`
+	Gift     *Item            `map:"gift"`
+	Archived []Item           `map:"archived"`
+	Saved    *[]*Item         `map:"saved"`
+}
+
+type Item struct {
+	Code  string  `map:"code"`
+	Price float32 `map:"price"`
+}
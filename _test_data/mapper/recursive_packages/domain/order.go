@@ -0,0 +1,8 @@
+package domain
+
+import "github.com/underbek/datamapper/_test_data/mapper/recursive_packages/domain/user"
+
+type Order struct {
+	ID   int64     `map:"id"`
+	User user.User `map:"user"`
+}
@@ -0,0 +1,6 @@
+package user
+
+type User struct {
+	ID   int64  `map:"id"`
+	Name string `map:"name"`
+}
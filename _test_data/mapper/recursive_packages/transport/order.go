@@ -0,0 +1,8 @@
+package transport
+
+import "github.com/underbek/datamapper/_test_data/mapper/recursive_packages/transport/user"
+
+type Order struct {
+	ID   string     `map:"id"`
+	User *user.User `map:"user"`
+}
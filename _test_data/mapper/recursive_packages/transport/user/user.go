@@ -0,0 +1,6 @@
+package user
+
+type User struct {
+	ID   string `map:"id"`
+	Name string `map:"name"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+type Item struct {
+	Title string `map:"title"`
+}
+
+type Product struct {
+	ID     int             `map:"id"`
+	Name   string          `map:"name"`
+	Price  float64         `map:"price"`
+	Count  int64           `map:"count"`
+	Code   int             `map:"code"`
+	Amount decimal.Decimal `map:"amount"`
+	Tags   []string        `map:"tags"`
+	Item   Item            `map:"item"`
+}
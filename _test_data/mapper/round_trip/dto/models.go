@@ -0,0 +1,16 @@
+package dto
+
+type Item struct {
+	Title string `map:"title"`
+}
+
+type Product struct {
+	ID     int      `map:"id"`
+	Name   string   `map:"name"`
+	Price  float64  `map:"price"`
+	Count  int32    `map:"count"`
+	Code   string   `map:"code"`
+	Amount string   `map:"amount"`
+	Tags   []string `map:"tags"`
+	Item   Item     `map:"item"`
+}
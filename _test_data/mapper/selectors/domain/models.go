@@ -0,0 +1,15 @@
+package domain
+
+type User struct {
+	ID   int64  `map:"id"`
+	Name string `map:"name"`
+}
+
+type Account struct {
+	ID     int64   `map:"id"`
+	Amount float64 `map:"amount"`
+}
+
+type Session struct {
+	Token string `map:"token"`
+}
@@ -0,0 +1,15 @@
+package dto
+
+type UserDTO struct {
+	ID   string `map:"id"`
+	Name string `map:"name"`
+}
+
+type AccountDTO struct {
+	ID     string  `map:"id"`
+	Amount float32 `map:"amount"`
+}
+
+type SessionDTO struct {
+	Token string `map:"token"`
+}
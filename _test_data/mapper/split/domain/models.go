@@ -0,0 +1,17 @@
+package domain
+
+type Role struct {
+	Name string `map:"name"`
+}
+
+type User struct {
+	ID   int64  `map:"id"`
+	Name string `map:"name"`
+	Role Role   `map:"role"`
+}
+
+type Address struct {
+	UserID int64  `addr:"id"`
+	City   string `addr:"city"`
+	Street string `addr:"street"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+type Role struct {
+	Name string `json:"name"`
+}
+
+type CreateUserRequest struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	City     string `json:"city"`
+	Street   string `json:"street"`
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
@@ -0,0 +1,15 @@
+package convertors
+
+import (
+	"errors"
+
+	"github.com/underbek/datamapper/_test_data/mapper/unconvertible/domain"
+)
+
+func ConvertMoneyToFloat(from *domain.Money) (float64, error) {
+	if from == nil {
+		return 0, errors.New("money is nil")
+	}
+
+	return float64(from.Amount), nil
+}
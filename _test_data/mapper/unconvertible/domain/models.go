@@ -0,0 +1,21 @@
+package domain
+
+type Status struct {
+	Code int
+}
+
+type Money struct {
+	Amount int
+}
+
+type Item struct {
+	ID    int   `map:"id"`
+	Price Money `map:"price"`
+}
+
+type Order struct {
+	ID      int    `map:"id"`
+	Status  Status `map:"status"`
+	Item    Item   `map:"item"`
+	Created Money  `map:"created"`
+}
@@ -0,0 +1,13 @@
+package transport
+
+type Item struct {
+	ID    string  `map:"id"`
+	Price float64 `map:"price"`
+}
+
+type Order struct {
+	ID      string `map:"id"`
+	Status  string `map:"status"`
+	Item    Item   `map:"item"`
+	Created string `map:"created"`
+}
@@ -0,0 +1,7 @@
+package domain
+
+type User struct {
+	ID   int     `map:"id"`
+	Name *string `map:"name"`
+	Age  *string `map:"age"`
+}
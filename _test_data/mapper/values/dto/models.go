@@ -0,0 +1,12 @@
+package dto
+
+type Kind string
+
+type User struct {
+	ID      string `json:"id"`
+	Name    string `json:"name,default=\"anonymous\""`
+	Age     int    `json:"age,default=18"`
+	Version int    `json:"version,const=2"`
+	Source  string `json:"source"`
+	Kind    Kind   `json:"kind,const=\"admin, owner\""`
+}
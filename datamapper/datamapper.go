@@ -0,0 +1,113 @@
+// Package datamapper provides API to generate convertors in memory from other generators.
+package datamapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/mapper"
+	"github.com/underbek/datamapper/options"
+)
+
+const (
+	FileNew       FileState = "new"
+	FileChanged   FileState = "changed"
+	FileUnchanged FileState = "unchanged"
+)
+
+type FileState string
+
+// FileSystem reads current destination files
+type FileSystem interface {
+	ReadFile(name string) ([]byte, error)
+}
+
+type Config struct {
+	// Options are generation options like in yaml config. Check and dry run modes are ignored
+	Options options.Options
+	// Logger is optional, messages are discarded if it is nil
+	Logger logger.Logger
+	// FS is optional, os file system is used if it is nil
+	FS FileSystem
+}
+
+type File struct {
+	Path       string
+	Package    string
+	Convertors []string
+	State      FileState
+}
+
+type Report struct {
+	// Files are generated files in order of generation
+	Files []File
+}
+
+type osFileSystem struct{}
+
+func (osFileSystem) ReadFile(name string) ([]byte, error) {
+	return os.ReadFile(name) //nolint:gosec
+}
+
+// Generate generates convertors by config and returns contents by destination paths.
+// It does not write files and keeps parsed packages only during the call.
+func Generate(ctx context.Context, cfg Config) (map[string][]byte, Report, error) {
+	lg := cfg.Logger
+	if lg == nil {
+		lg = logger.NewNop()
+	}
+
+	fsys := cfg.FS
+	if fsys == nil {
+		fsys = osFileSystem{}
+	}
+
+	sources, err := mapper.Generate(ctx, lg, cfg.Options)
+	if err != nil {
+		return nil, Report{}, err
+	}
+
+	files := make(map[string][]byte, len(sources))
+	report := Report{Files: make([]File, 0, len(sources))}
+	for _, source := range sources {
+		state, err := fileState(fsys, source.Destination, source.Content)
+		if err != nil {
+			return nil, Report{}, err
+		}
+
+		files[source.Destination] = source.Content
+		report.Files = append(report.Files, File{
+			Path:       source.Destination,
+			Package:    source.Package.Path,
+			Convertors: source.Convertors,
+			State:      state,
+		})
+	}
+
+	return files, report, nil
+}
+
+func fileState(fsys FileSystem, path string, content []byte) (FileState, error) {
+	if path == options.StdoutDestination {
+		return FileNew, nil
+	}
+
+	current, err := fsys.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return FileNew, nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("read destination %s error: %w", path, err)
+	}
+
+	if string(current) != string(content) {
+		return FileChanged, nil
+	}
+
+	return FileUnchanged, nil
+}
@@ -0,0 +1,111 @@
+package datamapper
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/datamapper/_test_data"
+	"github.com/underbek/datamapper/options"
+)
+
+const destination = "../_test_data/generated/mapper/datamapper_basket.go"
+
+type mapFileSystem map[string][]byte
+
+func (m mapFileSystem) ReadFile(name string) ([]byte, error) {
+	content, ok := m[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+
+	return content, nil
+}
+
+func basketConfig(fsys FileSystem) Config {
+	return Config{
+		Options: options.Options{
+			Layout: options.LayoutOption,
+			Options: []options.Option{
+				{
+					Destination: destination,
+					Recursive:   true,
+					Inverse:     true,
+					From: options.Model{
+						Source: "../_test_data/mapper/recursive_containers/domain",
+						Name:   "Basket",
+						Tag:    "map",
+					},
+					To: options.Model{
+						Source: "../_test_data/mapper/recursive_containers/dto",
+						Name:   "Basket",
+						Tag:    "map",
+					},
+				},
+			},
+		},
+		FS: fsys,
+	}
+}
+
+func Test_Generate(t *testing.T) {
+	expected := _test_data.MapperExpectedFile(t, "recursive_containers", "basket.go")
+
+	tests := []struct {
+		name  string
+		fsys  mapFileSystem
+		state FileState
+	}{
+		{
+			name:  "new file",
+			fsys:  mapFileSystem{},
+			state: FileNew,
+		},
+		{
+			name:  "changed file",
+			fsys:  mapFileSystem{destination: []byte("package mapper\n")},
+			state: FileChanged,
+		},
+		{
+			name:  "unchanged file",
+			fsys:  mapFileSystem{destination: []byte(expected)},
+			state: FileUnchanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, report, err := Generate(context.Background(), basketConfig(tt.fsys))
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string][]byte{destination: []byte(expected)}, files)
+			assert.Equal(t, Report{Files: []File{
+				{
+					Path:    destination,
+					Package: "github.com/underbek/datamapper/_test_data/generated/mapper",
+					Convertors: []string{
+						"ConvertDomainItemToDtoItem",
+						"ConvertDtoItemToDomainItem",
+						"ConvertDomainBasketToDtoBasket",
+						"ConvertDtoBasketToDomainBasket",
+					},
+					State: tt.state,
+				},
+			}}, report)
+
+			_, err = os.Stat(destination)
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func Test_GenerateCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := Generate(ctx, basketConfig(nil))
+	require.ErrorIs(t, err, context.Canceled)
+}
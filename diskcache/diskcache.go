@@ -0,0 +1,449 @@
+// Package diskcache stores parsed packages between runs by content hash of package sources.
+package diskcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// formatVersion must be changed with changes of cached models
+const formatVersion = "4"
+
+const (
+	KindPackage   = "package"
+	KindModels    = "models"
+	KindFunctions = "functions"
+	KindComputed  = "computed"
+)
+
+const develVersion = "(devel)"
+
+const (
+	dirPerm  = 0700
+	filePerm = 0600
+)
+
+// Cache is safe for concurrent use. Package hashes are computed once per Cache instance
+type Cache struct {
+	dir string
+
+	mu     sync.Mutex
+	hashes map[string]string
+	// importers are hashed package directories by directories of their imported packages
+	importers map[string]map[string]struct{}
+}
+
+type run struct {
+	Packages     map[string]string
+	Destinations map[string]string
+}
+
+func New(dir string) (*Cache, error) {
+	err := os.MkdirAll(dir, dirPerm)
+	if err != nil {
+		return nil, fmt.Errorf("create cache dir %s error: %w", dir, err)
+	}
+
+	return &Cache{
+		dir:       dir,
+		hashes:    make(map[string]string),
+		importers: make(map[string]map[string]struct{}),
+	}, nil
+}
+
+// Load decodes cached value of absolute source path into v.
+// Source is a package directory or a file, value is valid while its package is not changed
+func (c *Cache) Load(kind, source string, v any) bool {
+	hash, err := c.PackageHash(SourceDir(source))
+	if err != nil {
+		return false
+	}
+
+	return c.read(c.entryPath(kind, source, hash), v) == nil
+}
+
+// Has checks that any value of absolute source path is cached
+func (c *Cache) Has(source string) bool {
+	hash, err := c.PackageHash(SourceDir(source))
+	if err != nil {
+		return false
+	}
+
+	for _, kind := range []string{KindPackage, KindModels, KindFunctions, KindComputed} {
+		if _, err := os.Stat(c.entryPath(kind, source, hash)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Cache) Save(kind, source string, v any) error {
+	hash, err := c.PackageHash(SourceDir(source))
+	if err != nil {
+		return err
+	}
+
+	return c.write(c.entryPath(kind, source, hash), v)
+}
+
+// SourceDir returns package directory of source file or source itself
+func SourceDir(source string) string {
+	info, err := os.Stat(source)
+	if err == nil && !info.IsDir() {
+		return filepath.Dir(source)
+	}
+
+	return source
+}
+
+// UpToDate checks that packages and destinations of the previous run by key are not changed
+func (c *Cache) UpToDate(key string) bool {
+	var r run
+	if err := c.read(c.runPath(key), &r); err != nil {
+		return false
+	}
+
+	for dir, hash := range r.Packages {
+		current, err := c.PackageHash(dir)
+		if err != nil || current != hash {
+			return false
+		}
+	}
+
+	for dest, hash := range r.Destinations {
+		current, err := fileHash(dest)
+		if err != nil || current != hash {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SaveRun saves hashes of used packages and generated destinations by run key.
+// Hashes are computed again because destination packages are changed by the run
+func (c *Cache) SaveRun(key string, dirs, destinations []string) error {
+	c.Invalidate()
+
+	r := run{
+		Packages:     make(map[string]string, len(dirs)),
+		Destinations: make(map[string]string, len(destinations)),
+	}
+
+	for _, dir := range dirs {
+		hash, err := c.PackageHash(dir)
+		if err != nil {
+			return err
+		}
+
+		r.Packages[dir] = hash
+	}
+
+	for _, dest := range destinations {
+		hash, err := fileHash(dest)
+		if err != nil {
+			return err
+		}
+
+		r.Destinations[dest] = hash
+	}
+
+	return c.write(c.runPath(key), r)
+}
+
+// Invalidate forgets computed hashes of package directories and packages which import them,
+// so changed sources are hashed again. All hashes are forgotten without directories
+func (c *Cache) Invalidate(dirs ...string) {
+	if len(dirs) == 0 {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.hashes = make(map[string]string)
+		c.importers = make(map[string]map[string]struct{})
+		return
+	}
+
+	dependents := c.Dependents(dirs...)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, dir := range dependents {
+		delete(c.hashes, dir)
+	}
+}
+
+// Dependents returns directories and directories of hashed packages which import them directly or indirectly
+func (c *Cache) Dependents(dirs ...string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make(map[string]struct{}, len(dirs))
+	queue := append([]string(nil), dirs...)
+	for len(queue) != 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		if _, ok := res[dir]; ok {
+			continue
+		}
+
+		res[dir] = struct{}{}
+		for importer := range c.importers[dir] {
+			queue = append(queue, importer)
+		}
+	}
+
+	return sortedKeys(res)
+}
+
+// RunKey returns key of run by config and working directory
+func RunKey(config []byte) string {
+	wd, _ := os.Getwd()
+	return hashStrings(formatVersion, version(), runtime.Version(), wd, string(config))
+}
+
+// PackageHash returns hash of go files of package directory, go.mod and go.sum of its module
+// and hashes of imported packages from the same module
+func (c *Cache) PackageHash(dir string) (string, error) {
+	return c.packageHash(dir, make(map[string]struct{}))
+}
+
+func (c *Cache) packageHash(dir string, visiting map[string]struct{}) (string, error) {
+	c.mu.Lock()
+	hash, ok := c.hashes[dir]
+	c.mu.Unlock()
+	if ok {
+		return hash, nil
+	}
+
+	if _, ok := visiting[dir]; ok {
+		return "", nil
+	}
+	visiting[dir] = struct{}{}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(files)
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\n%s\n%s\n", formatVersion, version(), runtime.Version())
+
+	root := moduleRoot(dir)
+	for _, name := range []string{"go.mod", "go.sum"} {
+		if err := hashFile(h, filepath.Join(root, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	imports := make(map[string]struct{})
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		if err := hashFile(h, file); err != nil {
+			return "", err
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+
+		for _, imp := range f.Imports {
+			path, err := strconv.Unquote(imp.Path.Value)
+			if err == nil {
+				imports[path] = struct{}{}
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		pkg, err := build.Import(path, dir, build.FindOnly)
+		if err != nil || pkg.Goroot || !strings.HasPrefix(pkg.Dir, root+string(filepath.Separator)) {
+			// packages of other modules are checked by go.sum
+			continue
+		}
+
+		c.addImporter(pkg.Dir, dir)
+
+		importHash, err := c.packageHash(pkg.Dir, visiting)
+		if err != nil {
+			return "", err
+		}
+
+		_, _ = fmt.Fprintf(h, "%s %s\n", path, importHash)
+	}
+
+	hash = hex.EncodeToString(h.Sum(nil))
+
+	c.mu.Lock()
+	c.hashes[dir] = hash
+	c.mu.Unlock()
+
+	return hash, nil
+}
+
+func (c *Cache) addImporter(dir, importer string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.importers[dir] == nil {
+		c.importers[dir] = make(map[string]struct{})
+	}
+
+	c.importers[dir][importer] = struct{}{}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	res := make([]string, 0, len(set))
+	for key := range set {
+		res = append(res, key)
+	}
+
+	sort.Strings(res)
+	return res
+}
+
+func (c *Cache) entryPath(kind, source, hash string) string {
+	return filepath.Join(c.dir, hashStrings(kind, source, hash)+".gob")
+}
+
+func (c *Cache) runPath(key string) string {
+	return filepath.Join(c.dir, "run-"+key+".gob")
+}
+
+func (c *Cache) read(path string, v any) error {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+func (c *Cache) write(path string, v any) error {
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return err
+	}
+
+	// file is renamed to avoid reading of partially written entry by concurrent runs
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmp.Name(), filePerm); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func moduleRoot(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, "go.mod")); err == nil {
+			return current
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+
+		current = parent
+	}
+}
+
+func hashFile(h interface{ Write([]byte) (int, error) }, path string) error {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(h, "%s %d\n", filepath.Base(path), len(data))
+	_, _ = h.Write(data)
+
+	return nil
+}
+
+func fileHash(path string) (string, error) {
+	h := sha256.New()
+	if err := hashFile(h, path); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashStrings(values ...string) string {
+	h := sha256.New()
+	for _, value := range values {
+		_, _ = fmt.Fprintf(h, "%d:%s\n", len(value), value)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+var (
+	versionOnce  sync.Once
+	versionValue string
+)
+
+// version returns module version of datamapper.
+// Development builds have not version, so hash of the executable is used
+func version() string {
+	versionOnce.Do(func() {
+		info, ok := debug.ReadBuildInfo()
+		if ok && info.Main.Version != "" && info.Main.Version != develVersion {
+			versionValue = info.Main.Version
+			return
+		}
+
+		path, err := os.Executable()
+		if err != nil {
+			return
+		}
+
+		versionValue, _ = fileHash(path)
+	})
+
+	return versionValue
+}
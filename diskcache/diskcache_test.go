@@ -0,0 +1,157 @@
+package diskcache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/datamapper/models"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func Test_PackageHash(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "models.go"), "package models\n\ntype Model struct{ ID int }\n")
+
+	cache, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	hash, err := cache.PackageHash(dir)
+	require.NoError(t, err)
+
+	writeFile(t, filepath.Join(dir, "models_test.go"), "package models\n")
+	cache.Invalidate(dir)
+	testHash, err := cache.PackageHash(dir)
+	require.NoError(t, err)
+	assert.Equal(t, hash, testHash)
+
+	writeFile(t, filepath.Join(dir, "models.go"), "package models\n\ntype Model struct{ ID string }\n")
+	cached, err := cache.PackageHash(dir)
+	require.NoError(t, err)
+	assert.Equal(t, hash, cached)
+
+	cache.Invalidate(dir)
+	changed, err := cache.PackageHash(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
+
+func Test_LoadSave(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "models.go"), "package models\n")
+
+	cache, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	intType := models.Type{Name: "int", Kind: models.BaseType}
+	structs := map[string]models.Struct{
+		"Model": {
+			Type: models.Type{Name: "Model", Package: models.Package{Name: "models", Path: "pkg/models"}},
+			Fields: models.NewFields([]models.Field{
+				{Name: "ID", Type: intType, Tags: []models.Tag{{Name: "map", Value: "id"}}},
+				{
+					Name: "Values",
+					Type: models.Type{
+						Name: "map[string][]int",
+						Kind: models.MapType,
+						Additional: models.MapAdditional{
+							KeyType: models.Type{Name: "string"},
+							ValueType: models.Type{
+								Name:       "[]int",
+								Kind:       models.SliceType,
+								Additional: models.SliceAdditional{InType: intType},
+							},
+						},
+					},
+				},
+			}),
+		},
+	}
+
+	var res map[string]models.Struct
+	assert.False(t, cache.Load(KindModels, dir, &res))
+	assert.False(t, cache.Has(dir))
+
+	require.NoError(t, cache.Save(KindModels, dir, structs))
+	require.True(t, cache.Load(KindModels, dir, &res))
+	assert.Equal(t, structs, res)
+	assert.True(t, cache.Has(dir))
+	assert.False(t, cache.Load(KindFunctions, dir, &res))
+
+	writeFile(t, filepath.Join(dir, "models.go"), "package models\n\n// changed\n")
+	cache.Invalidate()
+	assert.False(t, cache.Load(KindModels, dir, &res))
+}
+
+func Test_UpToDate(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "models.go")
+	destination := filepath.Join(dir, "convertors.go")
+	writeFile(t, source, "package models\n")
+	writeFile(t, destination, "package models\n\n// generated\n")
+
+	cache, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	key := RunKey([]byte("options"))
+	assert.False(t, cache.UpToDate(key))
+
+	require.NoError(t, cache.SaveRun(key, []string{dir}, []string{destination}))
+	assert.True(t, cache.UpToDate(key))
+	assert.False(t, cache.UpToDate(RunKey([]byte("other options"))))
+
+	writeFile(t, destination, "package models\n\n// edited\n")
+	cache.Invalidate()
+	assert.False(t, cache.UpToDate(key))
+
+	require.NoError(t, cache.SaveRun(key, []string{dir}, []string{destination}))
+	writeFile(t, source, "package models\n\n// changed\n")
+	cache.Invalidate()
+	assert.False(t, cache.UpToDate(key))
+}
+
+func Test_VersionOfDevelBuild(t *testing.T) {
+	path, err := os.Executable()
+	require.NoError(t, err)
+
+	hash, err := fileHash(path)
+	require.NoError(t, err)
+	assert.Equal(t, hash, version())
+}
+
+func Test_InvalidateDependents(t *testing.T) {
+	const dependentsPath = "../_test_data/generated/dependents"
+	defer func() {
+		require.NoError(t, os.RemoveAll(dependentsPath))
+	}()
+
+	root, err := filepath.Abs(dependentsPath)
+	require.NoError(t, err)
+
+	user := filepath.Join(root, "user")
+	order := filepath.Join(root, "order")
+	require.NoError(t, os.MkdirAll(user, 0700))
+	require.NoError(t, os.MkdirAll(order, 0700))
+	writeFile(t, filepath.Join(user, "models.go"), "package user\n\ntype User struct{ ID int }\n")
+	writeFile(t, filepath.Join(order, "models.go"), "package order\n\n"+
+		"import \"github.com/underbek/datamapper/_test_data/generated/dependents/user\"\n\n"+
+		"type Order struct{ User user.User }\n")
+
+	cache, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	hash, err := cache.PackageHash(order)
+	require.NoError(t, err)
+	assert.Equal(t, []string{order, user}, cache.Dependents(user))
+
+	writeFile(t, filepath.Join(user, "models.go"), "package user\n\ntype User struct{ ID string }\n")
+	cache.Invalidate(user)
+	changed, err := cache.PackageHash(order)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/underbek/datamapper/models"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	applyFilePath      = "templates/apply.temp"
+	applyFieldFilePath = "templates/apply_field.temp"
+)
+
+// GenerateApply returns Apply{From}To{To} function which converts non-nil and non-zero fields of from model
+// and assigns them into existing to model. Nil embedded structs of to model are created
+func GenerateApply(from, to models.Struct, fromTag, toTag string, pkg models.Package, functions models.Functions) (
+	models.GeneratedConversionFunction, error,
+) {
+	packages := models.Packages{
+		from.Type.Package:                {},
+		to.Type.Package:                  {},
+		{Name: "errors", Path: "errors"}: {},
+	}
+
+	var fields []string
+	err := eachFieldsPair(from, to, pkg.Path, functions, pairOptions{partial: true}, func(
+		fromField, field models.Field, pair FieldsPair, packs models.Packages,
+	) error {
+		maps.Copy(packages, packs)
+
+		condition, withReflect := getApplyCondition(fromField)
+		if withReflect {
+			packages[models.Package{Name: "reflect", Path: "reflect"}] = struct{}{}
+		}
+
+		statements := getEmbeddedAllocations(field, pkg.Path)
+		statements = append(statements, pair.Conversions...)
+		statements = append(statements, fmt.Sprintf("to.%s = %s", createFieldPath(field), pair.Assignment))
+
+		res, err := fillTemplate[string](applyFieldFilePath, map[string]any{
+			"condition":  condition,
+			"statements": statements,
+		})
+		if err != nil {
+			return err
+		}
+
+		fields = append(fields, res)
+		return nil
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	if len(fields) == 0 {
+		return models.GeneratedConversionFunction{}, fmt.Errorf(
+			"%w %s by tag %s -> %s by tag %s",
+			ErrNothingToConvert,
+			from.Type.Name,
+			fromTag,
+			to.Type.Name,
+			toTag,
+		)
+	}
+
+	toType := to.Type
+	toType.Pointer = false
+
+	name := generateApplyName(from.Type, to.Type, pkg.Path)
+	body, err := fillTemplate[string](applyFilePath, map[string]any{
+		"name":        name,
+		"fromName":    from.Type.FullName(pkg.Path),
+		"toName":      toType.FullName(pkg.Path),
+		"fromTag":     fromTag,
+		"toTag":       toTag,
+		"fromPointer": from.Type.Pointer,
+		"fields":      fields,
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{Name: name, Package: pkg},
+		Packages: packages,
+		Body:     body,
+	}, nil
+}
+
+func generateApplyName(from, to models.Type, pkgPath string) string {
+	return fmt.Sprintf("Apply%sTo%s", structNameGenerator(from, pkgPath), structNameGenerator(to, pkgPath))
+}
+
+// getApplyCondition returns condition of non-nil embedded structs and non-zero value of from field.
+// It returns true if the condition uses reflect package
+func getApplyCondition(field models.Field) (string, bool) {
+	var conditions []string
+	for head := field.Head; head != nil; head = head.Head {
+		if head.Type.Pointer {
+			conditions = append([]string{createFieldPathWithPrefix(*head) + " != nil"}, conditions...)
+		}
+	}
+
+	path := createFieldPathWithPrefix(field)
+	withReflect := false
+
+	switch {
+	case field.Type.Pointer, field.Type.Kind == models.SliceType, field.Type.Kind == models.MapType,
+		field.Type.Kind == models.InterfaceType:
+		conditions = append(conditions, path+" != nil")
+	case field.Type.Kind == models.BaseType && field.Type.Name == "string":
+		conditions = append(conditions, path+` != ""`)
+	case field.Type.Kind == models.BaseType && field.Type.Name == "bool":
+		conditions = append(conditions, path)
+	case field.Type.Kind == models.BaseType:
+		conditions = append(conditions, path+" != 0")
+	default:
+		conditions = append(conditions, fmt.Sprintf("!reflect.ValueOf(%s).IsZero()", path))
+		withReflect = true
+	}
+
+	return strings.Join(conditions, " && "), withReflect
+}
+
+// getEmbeddedAllocations returns statements which create nil embedded structs of to field
+func getEmbeddedAllocations(field models.Field, pkgPath string) []string {
+	var res []string
+	for head := field.Head; head != nil; head = head.Head {
+		if !head.Type.Pointer {
+			continue
+		}
+
+		headType := head.Type
+		headType.Pointer = false
+
+		path := "to." + createFieldPath(*head)
+		res = append([]string{fmt.Sprintf(
+			"if %s == nil {\n%s = &%s{}\n}\n",
+			path,
+			path,
+			headType.FullName(pkgPath),
+		)}, res...)
+	}
+
+	return res
+}
@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/models"
+)
+
+// getComputedPair returns pair of to field which is assigned by computed function of whole from model.
+// Result of computed function with error is assigned by variable after error check
+func getComputedPair(to models.Field, fromModel, toModel models.Struct, pkgPath string, opts pairOptions,
+) (FieldsPair, models.Packages, error) {
+	cf := *to.Computed
+
+	pkgs := make(models.Packages)
+	if cf.Package.Path != "" {
+		pkgs[cf.Package] = struct{}{}
+	}
+
+	arg := opts.param()
+	fromPointer := fromModel.Type.Pointer || opts.pointerParam
+	switch {
+	case fromPointer && !cf.FromType.Pointer:
+		arg = "*" + arg
+	case !fromPointer && cf.FromType.Pointer:
+		arg = "&" + arg
+	}
+
+	pair := FieldsPair{
+		FromName:   cf.Name,
+		FromType:   fromModel.Type.Name,
+		ToName:     to.Name,
+		ToType:     to.Type.Name,
+		Assignment: fmt.Sprintf("%s(%s)", getConversionFunctionName(cf, pkgPath), arg),
+		WithError:  cf.WithError,
+	}
+
+	if !cf.WithError {
+		return pair, pkgs, nil
+	}
+
+	variable := "computed" + createAssignment(to)
+	conversion, err := getErrorConversion(
+		variable,
+		opts.resultValue(),
+		pair.Assignment,
+		fmt.Sprintf(`fmt.Errorf("compute %s.%s failed: %%w", err)`, toModel.Type.Name, createFieldPath(to)),
+	)
+	if err != nil {
+		return FieldsPair{}, nil, err
+	}
+
+	pkgs[models.Package{
+		Name: "fmt",
+		Path: "fmt",
+	}] = struct{}{}
+
+	pair.Conversions = append(pair.Conversions, conversion)
+	pair.Assignment = variable
+
+	return pair, pkgs, nil
+}
@@ -12,6 +12,8 @@ const (
 	NeedCallConversionFunctionWithErrorRule
 	PointerPoPointerConversionFunctionsRule
 	NeedRangeBySlice
+	NeedRangeByArray
+	NeedRangeByMap
 )
 
 func getConversionRule(fromType, toType models.Type, cf models.ConversionFunction) ConversionRule {
@@ -23,6 +25,14 @@ func getConversionRule(fromType, toType models.Type, cf models.ConversionFunctio
 		return NeedRangeBySlice
 	}
 
+	if isNeedRangeByArray(fromType, toType, cf) {
+		return NeedRangeByArray
+	}
+
+	if isNeedRangeByMap(fromType, toType, cf) {
+		return NeedRangeByMap
+	}
+
 	if isNeedCallConversionFunctionRule(fromType, toType, cf) {
 		return NeedCallConversionFunctionRule
 	}
@@ -98,19 +108,31 @@ func isNeedCallConversionFunctionSeparatelyRule(fromType, toType models.Type, cf
 }
 
 func isNeedRangeBySlice(fromType, toType models.Type, cf models.ConversionFunction) bool {
-	if fromType.Kind != models.SliceType {
+	return isNeedRangeByKind(models.SliceType, fromType, toType, cf)
+}
+
+func isNeedRangeByArray(fromType, toType models.Type, cf models.ConversionFunction) bool {
+	return isNeedRangeByKind(models.ArrayType, fromType, toType, cf)
+}
+
+func isNeedRangeByMap(fromType, toType models.Type, cf models.ConversionFunction) bool {
+	return isNeedRangeByKind(models.MapType, fromType, toType, cf)
+}
+
+func isNeedRangeByKind(kind models.KindOfType, fromType, toType models.Type, cf models.ConversionFunction) bool {
+	if fromType.Kind != kind {
 		return false
 	}
 
-	if toType.Kind != models.SliceType {
+	if toType.Kind != kind {
 		return false
 	}
 
-	if cf.FromType.Kind == models.SliceType {
+	if cf.FromType.Kind == kind {
 		return false
 	}
 
-	if cf.ToType.Kind == models.SliceType {
+	if cf.ToType.Kind == kind {
 		return false
 	}
 
@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/underbek/datamapper/models"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	diffFilePath      = "templates/diff.temp"
+	diffFieldFilePath = "templates/diff_field.temp"
+)
+
+// GenerateDiff returns Diff{From}{To} helper which converts mapped fields of from model like convertor
+// and returns paths of fields of to model which differ from them.
+// Fields which conversion can fail are compared by reverse conversion function if it exists and never fails
+func GenerateDiff(from, to models.Struct, fromTag, toTag string, pkg models.Package, functions models.Functions) (
+	models.GeneratedConversionFunction, error,
+) {
+	packages := models.Packages{
+		from.Type.Package:                  {},
+		to.Type.Package:                    {},
+		{Name: "fmt", Path: "fmt"}:         {},
+		{Name: "reflect", Path: "reflect"}: {},
+	}
+
+	toName := to.Type.FullName(pkg.Path)
+
+	var fields []string
+	err := eachFieldsPair(from, to, pkg.Path, functions, newPairOptions(to, pkg.Path), func(
+		fromField, field models.Field, pair FieldsPair, packs models.Packages,
+	) error {
+		maps.Copy(packages, packs)
+
+		data := map[string]any{
+			"path":        to.Type.Name + "." + createFieldPath(field),
+			"toName":      toName,
+			"toType":      getFullTypeName(field.Type, pkg.Path),
+			"toValue":     "to." + createFieldPath(field),
+			"toNilCheck":  getEmbeddedNilCheck(field),
+			"expected":    "expected" + createAssignment(field),
+			"resValue":    nilOrDefault(toName),
+			"assignment":  pair.Assignment,
+			"conversions": pair.Conversions,
+		}
+
+		// untyped constant is compared with value of field type
+		if field.Constant != "" {
+			data["assignment"] = getTypedConstant(field, pkg.Path)
+			TypePackages(field.Type, packages)
+		}
+
+		if pair.WithError && !withoutFromField(field) {
+			reverse, ok := getReverseConversion(fromField, field, functions)
+			if ok {
+				data["reverse"] = getConversionFunctionCall(
+					reverse,
+					field.Type,
+					fromField.Type,
+					pkg.Path,
+					"to."+createFieldPath(field),
+				)
+				data["fromValue"] = createFieldPathWithPrefix(fromField)
+
+				if reverse.Package.Path != "" {
+					packages[reverse.Package] = struct{}{}
+				}
+			}
+		}
+
+		res, err := fillTemplate[string](diffFieldFilePath, data)
+		if err != nil {
+			return err
+		}
+
+		fields = append(fields, res)
+		return nil
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	if len(fields) == 0 {
+		return models.GeneratedConversionFunction{}, fmt.Errorf(
+			"%w %s by tag %s -> %s by tag %s",
+			ErrNothingToConvert,
+			from.Type.Name,
+			fromTag,
+			to.Type.Name,
+			toTag,
+		)
+	}
+
+	name := generateDiffName(from.Type, to.Type, pkg.Path)
+	body, err := fillTemplate[string](diffFilePath, map[string]any{
+		"name":        name,
+		"fromName":    from.Type.FullName(pkg.Path),
+		"toName":      toName,
+		"fromPointer": from.Type.Pointer,
+		"toPointer":   to.Type.Pointer,
+		"fields":      fields,
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{Name: name, Package: pkg},
+		Packages: packages,
+		Body:     body,
+	}, nil
+}
+
+// getEmbeddedNilCheck returns condition of nil pointers of embedded structs of to field
+func getEmbeddedNilCheck(field models.Field) string {
+	var checks []string
+	for head := field.Head; head != nil; head = head.Head {
+		if head.Type.Pointer {
+			checks = append([]string{"to." + createFieldPath(*head) + " == nil"}, checks...)
+		}
+	}
+
+	return strings.Join(checks, " || ")
+}
+
+func generateDiffName(from, to models.Type, pkgPath string) string {
+	return fmt.Sprintf("Diff%s%s", structNameGenerator(from, pkgPath), structNameGenerator(to, pkgPath))
+}
+
+// getReverseConversion returns conversion function of to field into from field type which is called inline
+// without error. Fields of embedded structs are not compared reversely because embedded pointers can be nil
+func getReverseConversion(from, to models.Field, functions models.Functions) (models.ConversionFunction, bool) {
+	if from.Head != nil || to.Head != nil {
+		return models.ConversionFunction{}, false
+	}
+
+	cf, err := getConversionFunction(to.Type, from.Type, to.Name, functions)
+	if err != nil || cf.Name == "" || cf.WithError {
+		return models.ConversionFunction{}, false
+	}
+
+	if getConversionRule(to.Type, from.Type, cf) != NeedCallConversionFunctionRule {
+		return models.ConversionFunction{}, false
+	}
+
+	return cf, true
+}
+
+// getTypedConstant returns constant of to field converted into its type
+func getTypedConstant(field models.Field, pkgPath string) string {
+	typeName := getFullTypeName(field.Type, pkgPath)
+	if field.Type.Pointer {
+		typeName = "(" + typeName + ")"
+	}
+
+	return fmt.Sprintf("%s(%s)", typeName, field.Constant)
+}
@@ -1,14 +1,28 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"go/token"
+	"sort"
+	"strings"
 
 	"github.com/underbek/datamapper/models"
 )
 
 type FindFieldsPairError struct {
-	From          models.Type
-	To            models.Type
+	From models.Type
+	To   models.Type
+	// FromModel, ToModel and paths of fields are set for fields of models
+	FromModel models.Type
+	ToModel   models.Type
+	FromField string
+	ToField   string
+	// FromPosition and ToPosition are positions of fields declarations, they are invalid if unknown
+	FromPosition token.Position
+	ToPosition   token.Position
+	// Candidates are conversion functions of the same types which differ by pointers
+	Candidates    []models.ConversionFunction
 	fromFieldName string
 }
 
@@ -21,10 +35,181 @@ func NewFindFieldsPairError(from, to models.Type, fromFieldName string) *FindFie
 }
 
 func (e *FindFieldsPairError) Error() string {
+	msg := fmt.Sprintf(
+		"not found convertor function for types %s -> %s",
+		qualifiedTypeName(e.From),
+		qualifiedTypeName(e.To),
+	)
+
+	if e.FromField == "" {
+		msg += fmt.Sprintf(" by %s field", e.fromFieldName)
+	} else {
+		msg += fmt.Sprintf(
+			" by field %s.%s%s -> %s.%s%s",
+			qualifiedModelName(e.FromModel),
+			e.FromField,
+			formatPosition(e.FromPosition),
+			qualifiedModelName(e.ToModel),
+			e.ToField,
+			formatPosition(e.ToPosition),
+		)
+	}
+
+	if len(e.Candidates) == 0 {
+		return msg
+	}
+
+	candidates := make([]string, 0, len(e.Candidates))
+	for _, cf := range e.Candidates {
+		candidates = append(candidates, describeCandidate(cf))
+	}
+
+	return msg + ", candidates: " + strings.Join(candidates, ", ")
+}
+
+// findCandidates returns conversion functions of the same types with other pointers sorted by names
+func findCandidates(fromType, toType models.Type, functions models.Functions) []models.ConversionFunction {
+	var res []models.ConversionFunction
+	for key, cf := range functions {
+		if isSameTypesWithoutPointer(key.FromType, fromType) && isSameTypesWithoutPointer(key.ToType, toType) {
+			res = append(res, cf)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return describeCandidate(res[i]) < describeCandidate(res[j])
+	})
+
+	return res
+}
+
+func describeCandidate(cf models.ConversionFunction) string {
+	result := TypeName(cf.ToType)
+	if cf.WithError {
+		result = fmt.Sprintf("(%s, error)", result)
+	}
+
+	return fmt.Sprintf("%s(%s) %s", FunctionInstance(cf), TypeName(cf.FromType), result)
+}
+
+// qualifiedTypeName returns type expression with full package paths
+func qualifiedTypeName(t models.Type) string {
+	ptr := ""
+	if t.Pointer {
+		ptr = "*"
+	}
+
+	switch additional := t.Additional.(type) {
+	case models.SliceAdditional:
+		return fmt.Sprintf("%s[]%s", ptr, qualifiedTypeName(additional.InType))
+	case models.ArrayAdditional:
+		return fmt.Sprintf("%s[%d]%s", ptr, additional.Len, qualifiedTypeName(additional.InType))
+	case models.MapAdditional:
+		return fmt.Sprintf(
+			"%smap[%s]%s",
+			ptr,
+			qualifiedTypeName(additional.KeyType),
+			qualifiedTypeName(additional.ValueType),
+		)
+	}
+
+	if t.Package.Path == "" {
+		return ptr + t.Name
+	}
+
+	return fmt.Sprintf("%s%s.%s", ptr, t.Package.Path, t.Name)
+}
+
+func qualifiedModelName(t models.Type) string {
+	t.Pointer = false
+	return qualifiedTypeName(t)
+}
+
+// FieldError is an error of conversion of destination field of model
+type FieldError struct {
+	From      models.Type
+	To        models.Type
+	FromField models.Field
+	ToField   models.Field
+	// FromPosition and ToPosition are positions of fields declarations, they are invalid if unknown
+	FromPosition token.Position
+	ToPosition   token.Position
+	Err          error
+}
+
+// FromPath returns path of source field from its model through embedded fields
+func (e *FieldError) FromPath() string {
+	return e.From.Name + "." + createFieldPath(e.FromField)
+}
+
+// ToPath returns path of destination field from its model through embedded fields
+func (e *FieldError) ToPath() string {
+	return e.To.Name + "." + createFieldPath(e.ToField)
+}
+
+// SetPositions sets positions of fields declarations to the error and its FindFieldsPairError
+func (e *FieldError) SetPositions(from, to token.Position) {
+	e.FromPosition = from
+	e.ToPosition = to
+
+	var findErr *FindFieldsPairError
+	if errors.As(e.Err, &findErr) {
+		findErr.FromPosition = from
+		findErr.ToPosition = to
+	}
+}
+
+func (e *FieldError) Error() string {
+	// error of not found function describes fields itself
+	var findErr *FindFieldsPairError
+	if errors.As(e.Err, &findErr) && findErr.FromField != "" {
+		return e.Err.Error()
+	}
+
 	return fmt.Sprintf(
-		"not found convertor function for types %s -> %s by %s field",
-		e.From.Name,
-		e.To.Name,
-		e.fromFieldName,
+		"%s %s%s -> %s %s%s: %s",
+		e.FromPath(),
+		TypeName(e.FromField.Type),
+		formatPosition(e.FromPosition),
+		e.ToPath(),
+		TypeName(e.ToField.Type),
+		formatPosition(e.ToPosition),
+		e.Err,
 	)
 }
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldsError contains errors of all unconvertible fields
+type FieldsError struct {
+	Errors []*FieldError
+}
+
+func (e *FieldsError) Error() string {
+	lines := make([]string, 0, len(e.Errors)+1)
+	lines = append(lines, fmt.Sprintf("%d fields cannot be converted:", len(e.Errors)))
+	for _, err := range e.Errors {
+		lines = append(lines, "\t"+err.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns error of the first field, so errors.As finds it
+func (e *FieldsError) Unwrap() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e.Errors[0]
+}
+
+func formatPosition(pos token.Position) string {
+	if !pos.IsValid() {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s)", pos)
+}
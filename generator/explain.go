@@ -0,0 +1,242 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/underbek/datamapper/models"
+)
+
+var conversionRuleNames = map[ConversionRule]string{
+	UndefinedRule:                            "UndefinedRule",
+	NeedOnlyAssigmentRule:                    "NeedOnlyAssigmentRule",
+	NeedCallConversionFunctionRule:           "NeedCallConversionFunctionRule",
+	NeedCallConversionFunctionSeparatelyRule: "NeedCallConversionFunctionSeparatelyRule",
+	NeedCallConversionFunctionWithErrorRule:  "NeedCallConversionFunctionWithErrorRule",
+	PointerPoPointerConversionFunctionsRule:  "PointerPoPointerConversionFunctionsRule",
+	NeedRangeBySlice:                         "NeedRangeBySlice",
+	NeedRangeByArray:                         "NeedRangeByArray",
+	NeedRangeByMap:                           "NeedRangeByMap",
+}
+
+func (r ConversionRule) String() string {
+	return conversionRuleNames[r]
+}
+
+func (r ConversionRule) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *ConversionRule) UnmarshalText(text []byte) error {
+	for rule, name := range conversionRuleNames {
+		if name == string(text) {
+			*r = rule
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", ErrUndefinedConversionRule, text)
+}
+
+// ConvertorExplanation describes how fields of generated convertor are converted
+type ConvertorExplanation struct {
+	Name      string             `json:"name"`
+	From      string             `json:"from"`
+	To        string             `json:"to"`
+	WithError bool               `json:"with_error"`
+	Fields    []FieldExplanation `json:"fields"`
+}
+
+// FieldExplanation describes conversion of a destination field. Source fields are empty if it is not matched
+type FieldExplanation struct {
+	ToField   string         `json:"to_field"`
+	ToType    string         `json:"to_type"`
+	ToTag     string         `json:"to_tag"`
+	FromField string         `json:"from_field,omitempty"`
+	FromType  string         `json:"from_type,omitempty"`
+	FromTag   string         `json:"from_tag,omitempty"`
+	Rule      ConversionRule `json:"rule,omitempty"`
+	// ItemRule is a rule of items conversion of collections
+	ItemRule ConversionRule       `json:"item_rule,omitempty"`
+	Function *FunctionExplanation `json:"function,omitempty"`
+	Pointer  string               `json:"pointer,omitempty"`
+	// WithError is true if conversion of the field can return error
+	WithError bool `json:"with_error"`
+	// Computed is true if the field is assigned by computed function of whole source model of FromType
+	Computed bool `json:"computed,omitempty"`
+	// Constant is a Go expression assigned into the field instead of source field
+	Constant string `json:"constant,omitempty"`
+}
+
+type FunctionExplanation struct {
+	Name    string `json:"name"`
+	Package string `json:"package"`
+	// Location and Generated are filled by caller which has loaded packages
+	Location string `json:"location,omitempty"`
+	// Generated is true for convertors generated by the same run
+	Generated bool `json:"generated,omitempty"`
+
+	Function models.ConversionFunction `json:"-"`
+}
+
+// ExplainConvertor describes conversion of fields by the same rules as GenerateConvertor
+func ExplainConvertor(from, to models.Struct, pkg models.Package, functions models.Functions,
+) (ConvertorExplanation, error) {
+	res, err := createModelsPair(from, to, pkg.Path, functions)
+	if err != nil {
+		return ConvertorExplanation{}, err
+	}
+
+	explanation := ConvertorExplanation{
+		Name:      generateConvertorName(from.Type, to.Type, pkg.Path, models.StructType),
+		From:      from.Type.FullName(pkg.Path),
+		To:        to.Type.FullName(pkg.Path),
+		WithError: res.withError,
+	}
+
+	fromFields := make(map[string]models.Field)
+	from.Fields.Range(func(field models.Field) {
+		fromFields[field.Tags[0].Value] = field
+	})
+
+	err = to.Fields.Each(func(toField *models.Field) error {
+		field := FieldExplanation{
+			ToField: createFieldPath(*toField),
+			ToType:  getFullTypeName(toField.Type, pkg.Path),
+			ToTag:   formatTag(toField.Tags[0]),
+		}
+
+		if toField.Computed != nil {
+			explanation.Fields = append(explanation.Fields, explainComputed(field, *toField, from, pkg.Path))
+			return nil
+		}
+
+		if toField.Constant != "" {
+			field.Constant = toField.Constant
+			explanation.Fields = append(explanation.Fields, field)
+			return nil
+		}
+
+		fromField, ok := fromFields[toField.Tags[0].Value]
+		if !ok {
+			explanation.Fields = append(explanation.Fields, field)
+			return nil
+		}
+
+		field.FromField = createFieldPath(fromField)
+		field.FromType = getFullTypeName(fromField.Type, pkg.Path)
+		field.FromTag = formatTag(fromField.Tags[0])
+
+		pair, _, err := getFieldsPair(fromField, *toField, from, to, pkg.Path, functions, newPairOptions(to, pkg.Path))
+		if err != nil {
+			return err
+		}
+
+		cf, err := getConversionFunction(fromField.Type, toField.Type, fromField.Name, functions)
+		if err != nil {
+			return err
+		}
+
+		field.Rule = getConversionRule(fromField.Type, toField.Type, cf)
+		if isContainerRule(field.Rule) {
+			field.ItemRule = getConversionRule(getItemType(fromField.Type), getItemType(toField.Type), cf)
+		}
+
+		if cf.Name != "" {
+			field.Function = &FunctionExplanation{
+				Name:     cf.Name,
+				Package:  cf.Package.Path,
+				Function: cf,
+			}
+		}
+
+		field.Pointer = explainPointer(fromField, *toField, cf, field.Rule)
+		field.WithError = pair.WithError || pair.PointerToValue
+		explanation.Fields = append(explanation.Fields, field)
+
+		return nil
+	})
+	if err != nil {
+		return ConvertorExplanation{}, err
+	}
+
+	return explanation, nil
+}
+
+// explainComputed describes to field which is assigned by computed function of whole from model
+func explainComputed(field FieldExplanation, toField models.Field, from models.Struct, pkgPath string,
+) FieldExplanation {
+	cf := *toField.Computed
+
+	field.FromType = from.Type.FullName(pkgPath)
+	field.Computed = true
+	field.Rule = NeedCallConversionFunctionRule
+	if cf.WithError {
+		field.Rule = NeedCallConversionFunctionWithErrorRule
+	}
+
+	field.Function = &FunctionExplanation{
+		Name:     cf.Name,
+		Package:  cf.Package.Path,
+		Function: cf,
+	}
+	field.WithError = cf.WithError
+
+	return field
+}
+
+func formatTag(tag models.Tag) string {
+	return tag.Name + ":" + tag.Value
+}
+
+func isContainerRule(rule ConversionRule) bool {
+	return rule == NeedRangeBySlice || rule == NeedRangeByArray || rule == NeedRangeByMap
+}
+
+// explainPointer describes nil checks and pointer operations of generated conversion
+func explainPointer(from, to models.Field, cf models.ConversionFunction, rule ConversionRule) string {
+	var res []string
+	if isNeedPointerCheckSkippedFields(from) {
+		res = append(res, "nil embedded struct returns error")
+	}
+
+	if isNeedPointerCheckAndReturnError(from.Type, to.Type, cf) {
+		if to.Default != "" {
+			res = append(res, "nil is default "+to.Default)
+		} else {
+			res = append(res, "nil returns error")
+		}
+	}
+
+	switch rule {
+	case NeedOnlyAssigmentRule:
+		switch {
+		case from.Type.Pointer && !to.Type.Pointer:
+			res = append(res, "dereference")
+		case !from.Type.Pointer && to.Type.Pointer:
+			res = append(res, "address of value")
+		}
+	case NeedCallConversionFunctionRule:
+		if from.Type.Pointer && !cf.FromType.Pointer {
+			res = append(res, "dereference argument")
+		}
+	case NeedCallConversionFunctionSeparatelyRule:
+		res = append(res, "address of converted value")
+	case PointerPoPointerConversionFunctionsRule:
+		res = append(res, "nil is kept")
+	case NeedCallConversionFunctionWithErrorRule:
+		if to.Type.Pointer && !cf.ToType.Pointer {
+			res = append(res, "address of converted value")
+		}
+	case NeedRangeBySlice, NeedRangeByArray, NeedRangeByMap:
+		if from.Type.Pointer && to.Type.Pointer {
+			res = append(res, "nil collection is kept")
+		}
+
+		if isNeedPointerCheckAndReturnError(getItemType(from.Type), getItemType(to.Type), cf) {
+			res = append(res, "nil item returns error")
+		}
+	}
+
+	return strings.Join(res, ", ")
+}
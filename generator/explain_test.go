@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/datamapper/models"
+)
+
+func Test_ExplainConvertor(t *testing.T) {
+	pkg := models.Package{Name: generatedPackageName, Path: generatedPackagePath}
+	tag := func(value string) []models.Tag {
+		return []models.Tag{{Name: defaultTag, Value: value}}
+	}
+
+	fromModel := models.Struct{
+		Type: models.Type{Name: "FromName", Package: pkg},
+		Fields: models.NewFields([]models.Field{
+			{Name: "ID", Type: models.Type{Name: "int"}, Tags: tag("id")},
+			{Name: "Name", Type: models.Type{Name: "string", Pointer: true}, Tags: tag("name")},
+			{Name: "Age", Type: models.Type{Name: "string"}, Tags: tag("age")},
+		}),
+	}
+
+	toModel := models.Struct{
+		Type: models.Type{Name: "ToName", Package: pkg},
+		Fields: models.NewFields([]models.Field{
+			{Name: "UUID", Type: models.Type{Name: "string"}, Tags: tag("id")},
+			{Name: "Name", Type: models.Type{Name: "string"}, Tags: tag("name")},
+			{Name: "Age", Type: models.Type{Name: "int64", Pointer: true}, Tags: tag("age")},
+			{Name: "Data", Type: models.Type{Name: "string"}, Tags: tag("data")},
+		}),
+	}
+
+	res, err := ExplainConvertor(fromModel, toModel, pkg, parseFunctions(t, cfPath))
+	require.NoError(t, err)
+
+	assert.Equal(t, "ConvertFromNameToToName", res.Name)
+	assert.Equal(t, "FromName", res.From)
+	assert.Equal(t, "ToName", res.To)
+	assert.True(t, res.WithError)
+
+	for i := range res.Fields {
+		res.Fields[i].Function = functionWithoutModel(res.Fields[i].Function)
+	}
+
+	cfPackage := "github.com/underbek/datamapper/converts"
+	assert.Equal(t, []FieldExplanation{
+		{
+			ToField:   "UUID",
+			ToType:    "string",
+			ToTag:     "map:id",
+			FromField: "ID",
+			FromType:  "int",
+			FromTag:   "map:id",
+			Rule:      NeedCallConversionFunctionRule,
+			Function:  &FunctionExplanation{Name: "ConvertNumericToString", Package: cfPackage},
+		},
+		{
+			ToField:   "Name",
+			ToType:    "string",
+			ToTag:     "map:name",
+			FromField: "Name",
+			FromType:  "*string",
+			FromTag:   "map:name",
+			Rule:      NeedOnlyAssigmentRule,
+			Pointer:   "nil returns error, dereference",
+			WithError: true,
+		},
+		{
+			ToField:   "Age",
+			ToType:    "*int64",
+			ToTag:     "map:age",
+			FromField: "Age",
+			FromType:  "string",
+			FromTag:   "map:age",
+			Rule:      NeedCallConversionFunctionWithErrorRule,
+			Function:  &FunctionExplanation{Name: "ConvertStringToSigned", Package: cfPackage},
+			Pointer:   "address of converted value",
+			WithError: true,
+		},
+		{
+			ToField: "Data",
+			ToType:  "string",
+			ToTag:   "map:data",
+		},
+	}, res.Fields)
+}
+
+func functionWithoutModel(function *FunctionExplanation) *FunctionExplanation {
+	if function == nil {
+		return nil
+	}
+
+	return &FunctionExplanation{Name: function.Name, Package: function.Package}
+}
+
+func Test_ConversionRuleString(t *testing.T) {
+	assert.Equal(t, "NeedRangeByMap", NeedRangeByMap.String())
+
+	text, err := NeedOnlyAssigmentRule.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "NeedOnlyAssigmentRule", string(text))
+
+	var rule ConversionRule
+	require.NoError(t, rule.UnmarshalText(text))
+	assert.Equal(t, NeedOnlyAssigmentRule, rule)
+	assert.ErrorIs(t, rule.UnmarshalText([]byte("Unknown")), ErrUndefinedConversionRule)
+}
@@ -21,6 +21,9 @@ const (
 	pointerConversionFilePath          = "templates/pointer_conversion.temp"
 	pointerToPointerConversionFilePath = "templates/pointer_to_pointer_conversion.temp"
 	sliceConversionFilePath            = "templates/slice_conversion.temp"
+	arrayConversionFilePath            = "templates/array_conversion.temp"
+	mapConversionFilePath              = "templates/map_conversion.temp"
+	pointerContainerConversionFilePath = "templates/pointer_container_conversion.temp"
 	convertErrorFilePath               = "templates/convert_error.temp"
 )
 
@@ -105,10 +108,10 @@ func fillSliceConvertor(res sliceResult) (string, error) {
 	return fillTemplate[string](sliceConvertorFilePath, data)
 }
 
-func getPointerCheck(fromFullName, toModelName, err string, isError bool) (string, error) {
+func getPointerCheck(fromFullName, resValue, err string, isError bool) (string, error) {
 	data := map[string]any{
 		"fromFullName": fromFullName,
-		"resValue":     nilOrDefault(toModelName),
+		"resValue":     resValue,
 		"error":        err,
 		"isError":      isError,
 	}
@@ -116,7 +119,12 @@ func getPointerCheck(fromFullName, toModelName, err string, isError bool) (strin
 	return fillTemplate[string](pointerCheckFilePath, data)
 }
 
+// nilOrDefault returns empty value of model, it is empty if functions return only error
 func nilOrDefault(fullName string) string {
+	if fullName == "" {
+		return ""
+	}
+
 	if strings.HasPrefix(fullName, "*") {
 		return "nil"
 	}
@@ -124,9 +132,9 @@ func nilOrDefault(fullName string) string {
 	return fmt.Sprintf("%s{}", fullName)
 }
 
-func getErrorConversion(fromFieldFullName, toModelName, conversionFunction, err string) (string, error) {
+func getErrorConversion(fromFieldFullName, resValue, conversionFunction, err string) (string, error) {
 	data := map[string]any{
-		"resValue":           nilOrDefault(toModelName),
+		"resValue":           resValue,
 		"fromFieldFullName":  fromFieldFullName,
 		"conversionFunction": conversionFunction,
 		"error":              err,
@@ -144,13 +152,13 @@ func getPointerConversion(fromFieldFullName string, conversionFunction string) (
 	return fillTemplate[string](pointerConversionFilePath, data)
 }
 
-func getPointerToPointerConversion(fromFieldResName, fromFieldFullName, toModelName, toFullFieldType,
+func getPointerToPointerConversion(fromFieldResName, fromFieldFullName, resValue, toFullFieldType,
 	conversionFunction, err string, isError bool) (string, error) {
 
 	data := map[string]any{
 		"fromFieldResName":   fromFieldResName,
 		"fromFieldFullName":  fromFieldFullName,
-		"resValue":           nilOrDefault(toModelName),
+		"resValue":           resValue,
 		"toFullFieldType":    toFullFieldType,
 		"conversionFunction": conversionFunction,
 		"error":              err,
@@ -173,6 +181,47 @@ func getSliceConversion(fromFieldFullName, fromFieldPath, toItemTypeName, assigm
 	return fillTemplate[string](sliceConversionFilePath, data)
 }
 
+func getArrayConversion(fromFieldFullName, fromFieldPath, toItemTypeName string, length int64, assigment string,
+	conversions []string) (string, error) {
+	data := map[string]any{
+		"fromFieldFullName": fromFieldFullName,
+		"fromFieldPath":     fromFieldPath,
+		"toItemTypeName":    toItemTypeName,
+		"len":               length,
+		"assigment":         assigment,
+		"conversions":       conversions,
+	}
+
+	return fillTemplate[string](arrayConversionFilePath, data)
+}
+
+func getMapConversion(fromFieldFullName, fromFieldPath, keyTypeName, toItemTypeName, assigment string,
+	conversions []string) (string, error) {
+	data := map[string]any{
+		"fromFieldFullName": fromFieldFullName,
+		"fromFieldPath":     fromFieldPath,
+		"keyTypeName":       keyTypeName,
+		"toItemTypeName":    toItemTypeName,
+		"assigment":         assigment,
+		"conversions":       conversions,
+	}
+
+	return fillTemplate[string](mapConversionFilePath, data)
+}
+
+func getPointerContainerConversion(fromFieldFullName, fromFieldPath, toFieldTypeName, conversion, assigment string,
+) (string, error) {
+	data := map[string]any{
+		"fromFieldFullName": fromFieldFullName,
+		"fromFieldPath":     fromFieldPath,
+		"toFieldTypeName":   toFieldTypeName,
+		"conversion":        conversion,
+		"assigment":         assigment,
+	}
+
+	return fillTemplate[string](pointerContainerConversionFilePath, data)
+}
+
 func getConvertError(fromTypeName, fromFieldName, toTypeName, toFieldName string) (string, error) {
 	data := map[string]any{
 		"fromTypeName":  fromTypeName,
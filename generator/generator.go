@@ -61,8 +61,16 @@ type sliceResult struct {
 	withError     bool
 }
 
+// GeneratedHeader is the first line of every generated convertors source
+const GeneratedHeader = "// Code generated by datamapper."
+
+// GenerateConvertorSource returns formatted content of convertors source
+func GenerateConvertorSource(pkg models.Package, packages models.Packages, convertors []string) ([]byte, error) {
+	return fillConvertorsSource(pkg, packages, convertors)
+}
+
 func CreateConvertorSource(pkg models.Package, packages models.Packages, convertors []string, dest string) error {
-	content, err := fillConvertorsSource(pkg, packages, convertors)
+	content, err := GenerateConvertorSource(pkg, packages, convertors)
 	if err != nil {
 		return err
 	}
@@ -127,19 +135,24 @@ func GenerateConvertor(from, to models.Struct, fromTag, toTag string, pkg models
 	}
 
 	return models.GeneratedConversionFunction{
-		Function: models.ConversionFunction{
-			Name:      res.convertorName,
-			Package:   pkg,
-			FromType:  from.Type,
-			ToType:    to.Type,
-			TypeParam: models.NoTypeParam,
-			WithError: res.withError,
-		},
+		Function: ConvertorFunction(from.Type, to.Type, pkg, res.withError),
 		Packages: res.packages,
 		Body:     convertor,
 	}, nil
 }
 
+// ConvertorFunction returns conversion function of models convertor before its generation
+func ConvertorFunction(from, to models.Type, pkg models.Package, withError bool) models.ConversionFunction {
+	return models.ConversionFunction{
+		Name:      generateConvertorName(from, to, pkg.Path, models.StructType),
+		Package:   pkg,
+		FromType:  from,
+		ToType:    to,
+		TypeParam: models.NoTypeParam,
+		WithError: withError,
+	}
+}
+
 func GenerateSliceConvertor(from, to models.Type, pkg models.Package, cf models.ConversionFunction) (
 	models.GeneratedConversionFunction, error,
 ) {
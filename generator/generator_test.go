@@ -454,3 +454,461 @@ func Test_GenerateConvertorWithSlice(t *testing.T) {
 		})
 	}
 }
+
+func Test_FunctionInstance(t *testing.T) {
+	pkg := models.Package{Name: "converts", Path: "github.com/underbek/datamapper/converts"}
+	uuidType := models.Type{Name: "UUID", Package: models.Package{Name: "uuid", Path: "github.com/google/uuid"}}
+
+	tests := []struct {
+		name     string
+		cf       models.ConversionFunction
+		expected string
+	}{
+		{
+			name:     "Not generic",
+			cf:       models.ConversionFunction{Name: "ConvertUUIDToString", Package: pkg, FromType: uuidType},
+			expected: "converts.ConvertUUIDToString",
+		},
+		{
+			name: "From type param",
+			cf: models.ConversionFunction{
+				Name:      "ConvertNumericToString",
+				Package:   pkg,
+				FromType:  models.Type{Name: "int"},
+				TypeParam: models.FromTypeParam,
+			},
+			expected: "converts.ConvertNumericToString[int]",
+		},
+		{
+			name: "From to type params",
+			cf: models.ConversionFunction{
+				Name:      "ConvertOrderedToOrdered",
+				Package:   pkg,
+				FromType:  models.Type{Name: "int"},
+				ToType:    models.Type{Name: "float32", Pointer: true},
+				TypeParam: models.FromToTypeParam,
+			},
+			expected: "converts.ConvertOrderedToOrdered[int,*float32]",
+		},
+		{
+			name: "Local function",
+			cf: models.ConversionFunction{
+				Name:      "ConvertStringToSigned",
+				ToType:    models.Type{Name: "int64"},
+				TypeParam: models.ToTypeParam,
+			},
+			expected: "ConvertStringToSigned[int64]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FunctionInstance(tt.cf))
+		})
+	}
+}
+
+func Test_CreateModelsPairFieldsError(t *testing.T) {
+	pkg := models.Package{
+		Name: generatedPackageName,
+		Path: generatedPackagePath,
+	}
+
+	fromType := models.Type{Name: "FromName", Package: pkg}
+	toType := models.Type{Name: "ToName", Package: pkg}
+	statusType := models.Type{Name: "Status", Package: pkg, Kind: models.StructType}
+
+	fromModel := models.Struct{
+		Type: fromType,
+		Fields: models.NewFields([]models.Field{
+			{Name: "ID", Type: models.Type{Name: "int"}, Tags: []models.Tag{{Name: "map", Value: "id"}}},
+			{Name: "Status", Type: statusType, Tags: []models.Tag{{Name: "map", Value: "status"}}},
+			{Name: "Name", Type: models.Type{Name: "string"}, Tags: []models.Tag{{Name: "map", Value: "name"}}},
+			{Name: "Code", Type: statusType, Tags: []models.Tag{{Name: "map", Value: "code"}}},
+		}),
+	}
+
+	toModel := models.Struct{
+		Type: toType,
+		Fields: models.NewFields([]models.Field{
+			{Name: "ID", Type: models.Type{Name: "string"}, Tags: []models.Tag{{Name: "map", Value: "id"}}},
+			{Name: "State", Type: models.Type{Name: "string"}, Tags: []models.Tag{{Name: "map", Value: "status"}}},
+			{Name: "Name", Type: models.Type{Name: "string"}, Tags: []models.Tag{{Name: "map", Value: "name"}}},
+			{Name: "Code", Type: models.Type{Name: "int"}, Tags: []models.Tag{{Name: "map", Value: "code"}}},
+		}),
+	}
+
+	_, err := createModelsPair(fromModel, toModel, "", parseFunctions(t, cfPath))
+
+	var fieldsErr *FieldsError
+	require.ErrorAs(t, err, &fieldsErr)
+	require.Len(t, fieldsErr.Errors, 2)
+
+	assert.Equal(t, "FromName.Status", fieldsErr.Errors[0].FromPath())
+	assert.Equal(t, "ToName.State", fieldsErr.Errors[0].ToPath())
+	assert.Equal(t, "FromName.Code", fieldsErr.Errors[1].FromPath())
+	assert.Equal(t, "ToName.Code", fieldsErr.Errors[1].ToPath())
+
+	var findErr *FindFieldsPairError
+	require.ErrorAs(t, err, &findErr)
+	assert.Equal(t, statusType, findErr.From)
+	assert.Equal(t, models.Type{Name: "string"}, findErr.To)
+
+	assert.Equal(t, "Status", findErr.FromField)
+	assert.Equal(t, "State", findErr.ToField)
+	assert.Empty(t, findErr.Candidates)
+
+	assert.Equal(t,
+		"2 fields cannot be converted:\n"+
+			"\tnot found convertor function for types "+generatedPackagePath+".Status -> string "+
+			"by field "+generatedPackagePath+".FromName.Status -> "+generatedPackagePath+".ToName.State\n"+
+			"\tnot found convertor function for types "+generatedPackagePath+".Status -> int "+
+			"by field "+generatedPackagePath+".FromName.Code -> "+generatedPackagePath+".ToName.Code",
+		err.Error(),
+	)
+}
+
+func Test_FindFieldsPairErrorCandidates(t *testing.T) {
+	pkg := models.Package{
+		Name: generatedPackageName,
+		Path: generatedPackagePath,
+	}
+
+	statusType := models.Type{Name: "Status", Package: pkg, Kind: models.StructType}
+	statusPtrType := statusType
+	statusPtrType.Pointer = true
+	stringPtrType := models.Type{Name: "string", Pointer: true}
+
+	functions := models.Functions{
+		{FromType: statusPtrType, ToType: models.Type{Name: "string"}}: {
+			Name:     "StatusToString",
+			Package:  pkg,
+			FromType: statusPtrType,
+			ToType:   models.Type{Name: "string"},
+		},
+		{FromType: statusType, ToType: stringPtrType}: {
+			Name:      "StatusToStringPtr",
+			Package:   pkg,
+			FromType:  statusType,
+			ToType:    stringPtrType,
+			WithError: true,
+		},
+		{FromType: statusType, ToType: models.Type{Name: "int"}}: {
+			Name:     "StatusToInt",
+			Package:  pkg,
+			FromType: statusType,
+			ToType:   models.Type{Name: "int"},
+		},
+	}
+
+	_, err := getConversionFunction(statusType, models.Type{Name: "string"}, "Status", functions)
+
+	var findErr *FindFieldsPairError
+	require.ErrorAs(t, err, &findErr)
+	require.Len(t, findErr.Candidates, 2)
+	assert.Equal(t, "StatusToString", findErr.Candidates[0].Name)
+	assert.Equal(t, "StatusToStringPtr", findErr.Candidates[1].Name)
+
+	assert.Equal(t,
+		"not found convertor function for types "+generatedPackagePath+".Status -> string by Status field, "+
+			"candidates: generator.StatusToString(*generator.Status) string, "+
+			"generator.StatusToStringPtr(generator.Status) (*string, error)",
+		err.Error(),
+	)
+}
+
+func Test_GenerateStubsSource(t *testing.T) {
+	pkg := models.Package{Name: "convertors", Path: "github.com/underbek/datamapper/_test_data/convertors"}
+	domain := models.Package{Name: "domain", Path: "github.com/underbek/datamapper/_test_data/domain"}
+	otherDomain := models.Package{Name: "domain", Path: "github.com/underbek/datamapper/_test_data/other/domain", Alias: "otherdomain"}
+
+	money := models.Type{Name: "Money", Package: domain, Kind: models.StructType}
+	otherMoney := models.Type{Name: "Money", Package: otherDomain, Kind: models.StructType, Pointer: true}
+	stringsType := models.Type{
+		Name:       "[]string",
+		Kind:       models.SliceType,
+		Additional: models.SliceAdditional{InType: models.Type{Name: "string", Kind: models.BaseType}},
+	}
+
+	cfs := []models.ConversionFunction{
+		StubFunction(money, models.Type{Name: "string", Kind: models.BaseType}, pkg),
+		StubFunction(otherMoney, stringsType, pkg),
+	}
+
+	assert.Equal(t, "ConvertDomainMoneyToString", cfs[0].Name)
+	assert.Equal(t, "ConvertOtherdomainMoneyPtrToStringSlice", cfs[1].Name)
+
+	current := []byte(`package convertors
+
+import "strconv"
+
+// ConvertDomainMoneyToString is implemented
+func ConvertDomainMoneyToString(from int) string {
+	return strconv.Itoa(from)
+}
+`)
+
+	content, err := GenerateStubsSource(pkg, current, cfs)
+	require.NoError(t, err)
+
+	expected := `package convertors
+
+import (
+	"strconv"
+
+	otherdomain "github.com/underbek/datamapper/_test_data/other/domain"
+)
+
+// ConvertDomainMoneyToString is implemented
+func ConvertDomainMoneyToString(from int) string {
+	return strconv.Itoa(from)
+}
+
+// ConvertOtherdomainMoneyPtrToStringSlice converts *otherdomain.Money to []string
+func ConvertOtherdomainMoneyPtrToStringSlice(from *otherdomain.Money) []string {
+	panic("TODO")
+}
+`
+	assert.Equal(t, expected, string(content))
+}
+
+func Test_IsLossyFunction(t *testing.T) {
+	cf := models.ConversionFunction{
+		Name:    "ConvertMoneyToFloat",
+		Package: models.Package{Name: "convertors", Path: "github.com/underbek/datamapper/convertors"},
+	}
+
+	tests := []struct {
+		name  string
+		cf    models.ConversionFunction
+		lossy []string
+		want  bool
+	}{
+		{name: "empty", cf: cf, want: false},
+		{name: "by name", cf: cf, lossy: []string{"ConvertMoneyToFloat"}, want: true},
+		{name: "by package path", cf: cf, lossy: []string{"github.com/underbek/datamapper/convertors.ConvertMoneyToFloat"}, want: true},
+		{name: "other package", cf: cf, lossy: []string{"github.com/underbek/datamapper/other.ConvertMoneyToFloat"}, want: false},
+		{name: "other name", cf: cf, lossy: []string{"ConvertFloatToMoney"}, want: false},
+		{
+			name: "internal lossy function",
+			cf: models.ConversionFunction{
+				Name:    "ConvertDecimalToNumeric",
+				Package: models.Package{Name: "converts", Path: "github.com/underbek/datamapper/converts"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsLossyFunction(tt.cf, tt.lossy))
+		})
+	}
+}
+
+func Test_GenerateRoundTripTestWithoutFields(t *testing.T) {
+	pkg := models.Package{Name: generatedPackageName, Path: generatedPackagePath}
+	intType := models.Type{Name: "int", Kind: models.BaseType}
+	stringType := models.Type{Name: "string", Kind: models.BaseType}
+
+	fromModel := models.Struct{
+		Type: models.Type{Name: "From", Package: pkg, Kind: models.StructType},
+		Fields: models.NewFields([]models.Field{
+			{Name: "ID", Type: intType, Tags: []models.Tag{{Name: "map", Value: "id"}}},
+		}),
+	}
+	toModel := models.Struct{
+		Type: models.Type{Name: "To", Package: pkg, Kind: models.StructType},
+		Fields: models.NewFields([]models.Field{
+			{Name: "ID", Type: stringType, Tags: []models.Tag{{Name: "map", Value: "id"}}},
+		}),
+	}
+
+	converts := models.Package{Name: "converts", Path: "github.com/underbek/datamapper/converts"}
+	functions := models.Functions{
+		{FromType: fromModel.Type, ToType: toModel.Type}: {Name: "ConvertFromToTo", Package: pkg},
+		{FromType: toModel.Type, ToType: fromModel.Type}: {Name: "ConvertToToFrom", Package: pkg, WithError: true},
+		{FromType: intType, ToType: stringType}:          {Name: "ConvertIntToString", Package: converts},
+		{FromType: stringType, ToType: intType}:          {Name: "ConvertStringToInt", Package: converts, WithError: true},
+	}
+
+	res, err := GenerateRoundTripTest(fromModel, toModel, pkg, functions, nil)
+	require.NoError(t, err)
+	assert.Contains(t, res.Body, "FuzzConvertFromToToRoundTrip")
+
+	res, err = GenerateRoundTripTest(fromModel, toModel, pkg, functions, []string{"ConvertStringToInt"})
+	require.NoError(t, err)
+	assert.Empty(t, res.Body)
+
+	_, err = GenerateRoundTripTest(toModel, fromModel, pkg, models.Functions{}, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func Test_GenerateDiff(t *testing.T) {
+	pkg := models.Package{Name: generatedPackageName, Path: generatedPackagePath}
+	intType := models.Type{Name: "int", Kind: models.BaseType}
+	stringType := models.Type{Name: "string", Kind: models.BaseType}
+
+	fromModel := models.Struct{
+		Type: models.Type{Name: "From", Package: pkg, Kind: models.StructType},
+		Fields: models.NewFields([]models.Field{
+			{Name: "ID", Type: intType, Tags: []models.Tag{{Name: "map", Value: "id"}}},
+		}),
+	}
+	toModel := models.Struct{
+		Type: models.Type{Name: "To", Package: pkg, Kind: models.StructType},
+		Fields: models.NewFields([]models.Field{
+			{Name: "ID", Type: stringType, Tags: []models.Tag{{Name: "map", Value: "id"}}},
+		}),
+	}
+
+	converts := models.Package{Name: "converts", Path: "github.com/underbek/datamapper/converts"}
+	functions := models.Functions{
+		{FromType: intType, ToType: stringType}: {Name: "ConvertIntToString", Package: converts},
+		{FromType: stringType, ToType: intType}: {Name: "ConvertStringToInt", Package: converts, WithError: true},
+	}
+
+	t.Run("direct", func(t *testing.T) {
+		res, err := GenerateDiff(fromModel, toModel, "map", "map", pkg, functions)
+		require.NoError(t, err)
+		assert.Equal(t, "DiffFromTo", res.Function.Name)
+		assert.Contains(t, res.Body, "if expected := converts.ConvertIntToString(from.ID); !reflect.DeepEqual(expected, to.ID)")
+	})
+
+	t.Run("by reverse function", func(t *testing.T) {
+		res, err := GenerateDiff(toModel, fromModel, "map", "map", pkg, functions)
+		require.NoError(t, err)
+		assert.Equal(t, "DiffToFrom", res.Function.Name)
+		assert.Contains(t, res.Body, "if actual := converts.ConvertIntToString(to.ID); !reflect.DeepEqual(from.ID, actual)")
+	})
+
+	t.Run("nothing to compare", func(t *testing.T) {
+		other := toModel
+		other.Fields = models.NewFields([]models.Field{
+			{Name: "Name", Type: stringType, Tags: []models.Tag{{Name: "map", Value: "name"}}},
+		})
+
+		_, err := GenerateDiff(fromModel, other, "map", "map", pkg, functions)
+		assert.ErrorIs(t, err, ErrNothingToConvert)
+	})
+}
+
+func Test_GetApplyCondition(t *testing.T) {
+	user := models.Type{Name: "User", Kind: models.StructType, Pointer: true}
+	head := &models.Field{Name: "User", Type: user}
+
+	tests := []struct {
+		name        string
+		field       models.Field
+		condition   string
+		withReflect bool
+	}{
+		{
+			name:      "pointer",
+			field:     models.Field{Name: "ID", Type: models.Type{Name: "string", Kind: models.BaseType, Pointer: true}},
+			condition: "from.ID != nil",
+		},
+		{
+			name:      "string",
+			field:     models.Field{Name: "Name", Type: models.Type{Name: "string", Kind: models.BaseType}},
+			condition: `from.Name != ""`,
+		},
+		{
+			name:      "bool",
+			field:     models.Field{Name: "Active", Type: models.Type{Name: "bool", Kind: models.BaseType}},
+			condition: "from.Active",
+		},
+		{
+			name:      "number",
+			field:     models.Field{Name: "Age", Type: models.Type{Name: "uint8", Kind: models.BaseType}},
+			condition: "from.Age != 0",
+		},
+		{
+			name:      "slice",
+			field:     models.Field{Name: "Tags", Type: models.Type{Name: "[]string", Kind: models.SliceType}},
+			condition: "from.Tags != nil",
+		},
+		{
+			name:        "struct",
+			field:       models.Field{Name: "CreatedAt", Type: models.Type{Name: "Time", Kind: models.StructType}},
+			condition:   "!reflect.ValueOf(from.CreatedAt).IsZero()",
+			withReflect: true,
+		},
+		{
+			name:      "field of embedded pointer",
+			field:     models.Field{Name: "ID", Type: models.Type{Name: "int", Kind: models.BaseType}, Head: head},
+			condition: "from.User != nil && from.User.ID != 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, withReflect := getApplyCondition(tt.field)
+			assert.Equal(t, tt.condition, condition)
+			assert.Equal(t, tt.withReflect, withReflect)
+		})
+	}
+}
+
+func Test_GenerateInPlaceName(t *testing.T) {
+	pkg := models.Package{Name: "domain", Path: "github.com/underbek/datamapper/domain"}
+	token := models.Type{Name: "Token", Kind: models.StructType, Package: pkg, Pointer: true}
+	tokenData := models.Type{
+		Name:    "TokenData",
+		Kind:    models.StructType,
+		Package: models.Package{Name: "dto", Path: "github.com/underbek/datamapper/dto"},
+	}
+
+	assert.Equal(t,
+		"ConvertTokenIntoDtoTokenData",
+		generateInPlaceName(token, tokenData, pkg.Path, models.StructType),
+	)
+	assert.Equal(t,
+		"ConvertTokenSliceIntoDtoTokenDataSlice",
+		generateInPlaceName(token, tokenData, pkg.Path, models.SliceType),
+	)
+	assert.Equal(t, "Token", modelName(token, pkg.Path))
+}
+
+func Test_CheckMergeParams(t *testing.T) {
+	user := models.Struct{Type: models.Type{Name: "User", Kind: models.StructType}}
+	account := models.Struct{Type: models.Type{Name: "Account", Kind: models.StructType}}
+
+	tests := []struct {
+		name    string
+		sources []Source
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			sources: []Source{{Model: user, Param: "user"}, {Model: account, Param: "acc"}},
+		},
+		{
+			name:    "keyword",
+			sources: []Source{{Model: user, Param: "type"}},
+			wantErr: true,
+		},
+		{
+			name:    "blank",
+			sources: []Source{{Model: user, Param: "_"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate",
+			sources: []Source{{Model: user, Param: "user"}, {Model: account, Param: "user"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMergeParams(tt.sources)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrMergeParam)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
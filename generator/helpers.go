@@ -23,6 +23,31 @@ func getTypeParams(cf models.ConversionFunction, fromType, toType models.Type) s
 	}
 }
 
+// FunctionInstance returns name of conversion function with its package and all type arguments,
+// inferred type arguments are shown too
+func FunctionInstance(cf models.ConversionFunction) string {
+	name := cf.Name
+	switch cf.TypeParam {
+	case models.FromTypeParam:
+		name += fmt.Sprintf("[%s]", TypeName(cf.FromType))
+	case models.ToTypeParam:
+		name += fmt.Sprintf("[%s]", TypeName(cf.ToType))
+	case models.FromToTypeParam:
+		name += fmt.Sprintf("[%s,%s]", TypeName(cf.FromType), TypeName(cf.ToType))
+	}
+
+	if cf.Package.Name == "" {
+		return name
+	}
+
+	return cf.Package.Name + "." + name
+}
+
+// TypeName returns type expression with package name and collections
+func TypeName(t models.Type) string {
+	return getFullTypeName(t, "")
+}
+
 func fillConversions(fields []FieldsPair) []string {
 	uniqConversions := make(map[string]struct{})
 	var res []string
@@ -62,21 +87,22 @@ func filterAndSortImports(currentPkgPath string, imports []ImportType) []ImportT
 	return res
 }
 
-func generateConvertorName(from, to models.Type, pkgPath string, kind models.KindOfType) string {
-	structNameGenerator := func(t models.Type, pkgPath string) string {
-		name := t.Name
+// structNameGenerator returns model name prefixed by its package name if it is not in pkgPath package
+func structNameGenerator(t models.Type, pkgPath string) string {
+	name := t.Name
 
-		if t.Package.Path == pkgPath {
-			return name
-		}
+	if t.Package.Path == pkgPath {
+		return name
+	}
 
-		pkgName := t.Package.Name
-		if t.Package.Alias != "" {
-			pkgName = t.Package.Alias
-		}
-		return cases.Title(language.Und, cases.NoLower).String(pkgName) + name
+	pkgName := t.Package.Name
+	if t.Package.Alias != "" {
+		pkgName = t.Package.Alias
 	}
+	return cases.Title(language.Und, cases.NoLower).String(pkgName) + name
+}
 
+func generateConvertorName(from, to models.Type, pkgPath string, kind models.KindOfType) string {
 	prefix := ""
 	switch kind {
 	case models.SliceType:
@@ -124,16 +150,74 @@ func getConversionFunction(fromType, toType models.Type, fromName string, functi
 		return cf, nil
 	}
 
-	if !ok && fromType.Kind == models.SliceType && toType.Kind == models.SliceType {
+	if isSameContainers(fromType, toType) {
 		return getConversionFunction(
-			fromType.Additional.(models.SliceAdditional).InType,
-			toType.Additional.(models.SliceAdditional).InType,
+			getItemType(fromType),
+			getItemType(toType),
 			fromName,
 			functions,
 		)
 	}
 
-	return models.ConversionFunction{}, NewFindFieldsPairError(fromType, toType, fromName)
+	err := NewFindFieldsPairError(fromType, toType, fromName)
+	err.Candidates = findCandidates(fromType, toType, functions)
+
+	return models.ConversionFunction{}, err
+}
+
+// isSameContainers checks that types are collections of the same kind with items which can be converted separately
+func isSameContainers(fromType, toType models.Type) bool {
+	if fromType.Kind != toType.Kind {
+		return false
+	}
+
+	switch fromType.Kind {
+	case models.SliceType:
+		return true
+	case models.ArrayType:
+		return fromType.Additional.(models.ArrayAdditional).Len == toType.Additional.(models.ArrayAdditional).Len
+	case models.MapType:
+		return fromType.Additional.(models.MapAdditional).KeyType == toType.Additional.(models.MapAdditional).KeyType
+	default:
+		return false
+	}
+}
+
+func getItemType(t models.Type) models.Type {
+	switch additional := t.Additional.(type) {
+	case models.SliceAdditional:
+		return additional.InType
+	case models.ArrayAdditional:
+		return additional.InType
+	case models.MapAdditional:
+		return additional.ValueType
+	default:
+		return models.Type{}
+	}
+}
+
+// getFullTypeName returns type expression with collections by base package
+func getFullTypeName(t models.Type, pkgPath string) string {
+	ptr := ""
+	if t.Pointer {
+		ptr = "*"
+	}
+
+	switch additional := t.Additional.(type) {
+	case models.SliceAdditional:
+		return fmt.Sprintf("%s[]%s", ptr, getFullTypeName(additional.InType, pkgPath))
+	case models.ArrayAdditional:
+		return fmt.Sprintf("%s[%d]%s", ptr, additional.Len, getFullTypeName(additional.InType, pkgPath))
+	case models.MapAdditional:
+		return fmt.Sprintf(
+			"%smap[%s]%s",
+			ptr,
+			getFullTypeName(additional.KeyType, pkgPath),
+			getFullTypeName(additional.ValueType, pkgPath),
+		)
+	default:
+		return t.FullName(pkgPath)
+	}
 }
 
 func getPointerSymbol(fromFieldType, cfFromType models.Type) string {
@@ -171,15 +255,16 @@ func getFieldPointerCheckError(fromModelName, toModelName, fromFieldName, toFiel
 	)
 }
 
-func getSkippedFieldsPointerCheckError(field models.Field, toTypeFullName, fromTypeName string) ([]string, error) {
+func getSkippedFieldsPointerCheckError(field models.Field, param, resValue, fromTypeName string,
+) ([]string, error) {
 	var res []string
 
 	head := field.Head
 	for head != nil {
 		if head.Type.Pointer {
 			conversion, err := getPointerCheck(
-				createFieldPathWithPrefix(*head),
-				toTypeFullName,
+				createFieldPathWithParam(param, *head),
+				resValue,
 				fmt.Sprintf("errors.New(\"%s.%s is nil\")", fromTypeName, createFieldPath(*head)),
 				true,
 			)
@@ -187,7 +272,8 @@ func getSkippedFieldsPointerCheckError(field models.Field, toTypeFullName, fromT
 				return nil, err
 			}
 
-			res = append(res, conversion)
+			// outer embedded structs are checked first
+			res = append([]string{conversion}, res...)
 		}
 
 		head = head.Head
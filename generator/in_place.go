@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/underbek/datamapper/models"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	inPlaceFilePath          = "templates/in_place.temp"
+	inPlaceSliceFilePath     = "templates/in_place_slice.temp"
+	inPlaceBenchmarkFilePath = "templates/in_place_benchmark.temp"
+
+	// benchmarkSliceSize is a number of models converted by one iteration of benchmarks
+	benchmarkSliceSize = 1000
+)
+
+// GenerateInPlaceConvertor returns Convert{From}Into{To} function which converts from model into existing to model.
+// Nil embedded structs of to model are created, other embedded structs are reused
+func GenerateInPlaceConvertor(from, to models.Struct, fromTag, toTag string, pkg models.Package,
+	functions models.Functions) (models.GeneratedConversionFunction, error) {
+
+	packages := models.Packages{
+		from.Type.Package:                {},
+		to.Type.Package:                  {},
+		{Name: "errors", Path: "errors"}: {},
+	}
+
+	var pairs []FieldsPair
+	var allocations []string
+	uniqAllocations := make(map[string]struct{})
+	var assignments []string
+	err := eachFieldsPair(from, to, pkg.Path, functions, pairOptions{pointerParam: true}, func(
+		_, field models.Field, pair FieldsPair, packs models.Packages,
+	) error {
+		maps.Copy(packages, packs)
+		pairs = append(pairs, pair)
+
+		for _, allocation := range getEmbeddedAllocations(field, pkg.Path) {
+			if _, ok := uniqAllocations[allocation]; !ok {
+				uniqAllocations[allocation] = struct{}{}
+				allocations = append(allocations, allocation)
+			}
+		}
+
+		assignments = append(assignments, fmt.Sprintf("to.%s = %s", createFieldPath(field), pair.Assignment))
+		return nil
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	if len(pairs) == 0 {
+		return models.GeneratedConversionFunction{}, fmt.Errorf(
+			"%w %s by tag %s -> %s by tag %s",
+			ErrNothingToConvert,
+			from.Type.Name,
+			fromTag,
+			to.Type.Name,
+			toTag,
+		)
+	}
+
+	name := generateInPlaceName(from.Type, to.Type, pkg.Path, models.StructType)
+	body, err := fillTemplate[string](inPlaceFilePath, map[string]any{
+		"name":        name,
+		"fromName":    modelName(from.Type, pkg.Path),
+		"toName":      modelName(to.Type, pkg.Path),
+		"fromTag":     fromTag,
+		"toTag":       toTag,
+		"conversions": fillConversions(pairs),
+		"allocations": allocations,
+		"assignments": assignments,
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{Name: name, Package: pkg},
+		Packages: packages,
+		Body:     body,
+	}, nil
+}
+
+// GenerateInPlaceSliceConvertor returns Convert{From}SliceInto{To}Slice function which converts slice of from models
+// by in-place convertor into slice of to models and reuses its capacity
+func GenerateInPlaceSliceConvertor(from, to models.Struct, _, _ string, pkg models.Package, _ models.Functions,
+) (models.GeneratedConversionFunction, error) {
+	name := generateInPlaceName(from.Type, to.Type, pkg.Path, models.SliceType)
+	body, err := fillTemplate[string](inPlaceSliceFilePath, map[string]any{
+		"name":          name,
+		"fromName":      modelName(from.Type, pkg.Path),
+		"toName":        modelName(to.Type, pkg.Path),
+		"convertorName": generateInPlaceName(from.Type, to.Type, pkg.Path, models.StructType),
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{Name: name, Package: pkg},
+		Packages: models.Packages{
+			from.Type.Package:          {},
+			to.Type.Package:            {},
+			{Name: "fmt", Path: "fmt"}: {},
+		},
+		Body: body,
+	}, nil
+}
+
+// GenerateInPlaceBenchmark returns benchmark which compares convertor of models and in-place convertor by slices.
+// Convertor of models must be in functions
+func GenerateInPlaceBenchmark(from, to models.Struct, _, _ string, pkg models.Package, functions models.Functions,
+) (models.GeneratedConversionFunction, error) {
+	convertor, ok := functions[models.ConversionFunctionKey{FromType: from.Type, ToType: to.Type}]
+	if !ok {
+		return models.GeneratedConversionFunction{}, fmt.Errorf(
+			"%w: convertor %s -> %s",
+			ErrNotFound,
+			from.Type.Name,
+			to.Type.Name,
+		)
+	}
+
+	inPlaceName := generateInPlaceName(from.Type, to.Type, pkg.Path, models.StructType)
+	name := "Benchmark" + inPlaceName
+	body, err := fillTemplate[string](inPlaceBenchmarkFilePath, map[string]any{
+		"name":             name,
+		"size":             benchmarkSliceSize,
+		"fromName":         modelName(from.Type, pkg.Path),
+		"fromPointer":      from.Type.Pointer,
+		"toName":           modelName(to.Type, pkg.Path),
+		"toResultName":     to.Type.FullName(pkg.Path),
+		"convertorName":    getConversionFunctionName(convertor, pkg.Path),
+		"withError":        convertor.WithError,
+		"inPlaceName":      inPlaceName,
+		"inPlaceSliceName": generateInPlaceName(from.Type, to.Type, pkg.Path, models.SliceType),
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{Name: name, Package: pkg},
+		Packages: models.Packages{
+			from.Type.Package:                  {},
+			to.Type.Package:                    {},
+			{Name: "testing", Path: "testing"}: {},
+		},
+		Body: body,
+	}, nil
+}
+
+func generateInPlaceName(from, to models.Type, pkgPath string, kind models.KindOfType) string {
+	suffix := ""
+	if kind == models.SliceType {
+		suffix = "Slice"
+	}
+
+	return fmt.Sprintf(
+		"Convert%s%sInto%s%s",
+		structNameGenerator(from, pkgPath),
+		suffix,
+		structNameGenerator(to, pkgPath),
+		suffix,
+	)
+}
+
+// modelName returns name of model without pointer
+func modelName(t models.Type, pkgPath string) string {
+	t.Pointer = false
+	return t.FullName(pkgPath)
+}
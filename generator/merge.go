@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/underbek/datamapper/models"
+	"golang.org/x/exp/maps"
+)
+
+const mergeConvertorFilePath = "templates/merge_convertor.temp"
+
+var ErrMergeParam = errors.New("merge parameter error")
+
+// Source is a from model of merge convertor which is passed into convertor by Param
+type Source struct {
+	Model models.Struct
+	Tag   string
+	Param string
+}
+
+// GenerateMergeConvertor returns convertor of several from models into one to model.
+// Field of to model is converted from field of the first source with the same tag value
+func GenerateMergeConvertor(sources []Source, to models.Struct, toTag string, pkg models.Package,
+	functions models.Functions) (models.GeneratedConversionFunction, error) {
+
+	if err := checkMergeParams(sources); err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	packages := models.Packages{to.Type.Package: {}}
+	sourcesFields := make([]map[string]models.Field, 0, len(sources))
+	for _, source := range sources {
+		packages[source.Model.Type.Package] = struct{}{}
+		sourcesFields = append(sourcesFields, fieldsByTag(source.Model))
+	}
+
+	toName := to.Type.FullName(pkg.Path)
+
+	var fields []FieldsPair
+	var fieldErrors []*FieldError
+	_ = to.Fields.Each(func(field *models.Field) error {
+		for i, source := range sources {
+			fromField, ok := sourcesFields[i][field.Tags[0].Value]
+			if !ok {
+				continue
+			}
+
+			opts := pairOptions{resultModels: []string{toName}, fromParam: source.Param}
+			pair, packs, err := createFieldsPair(fromField, *field, source.Model, to, pkg.Path, functions, opts)
+			if err != nil {
+				fieldErrors = append(fieldErrors, &FieldError{
+					From:      source.Model.Type,
+					To:        to.Type,
+					FromField: fromField,
+					ToField:   *field,
+					Err:       err,
+				})
+				return nil
+			}
+
+			fields = append(fields, pair)
+			maps.Copy(packages, packs)
+			return nil
+		}
+
+		return nil
+	})
+
+	if len(fieldErrors) != 0 {
+		return models.GeneratedConversionFunction{}, &FieldsError{Errors: fieldErrors}
+	}
+
+	if len(fields) == 0 {
+		return models.GeneratedConversionFunction{}, fmt.Errorf(
+			"%w %s -> %s by tag %s",
+			ErrNothingToConvert,
+			strings.Join(mergeSourcesNames(sources, pkg.Path), ", "),
+			to.Type.Name,
+			toTag,
+		)
+	}
+
+	withError := isReturnError(fields)
+	for _, source := range sources {
+		withError = withError || source.Model.Type.Pointer && !to.Type.Pointer
+	}
+
+	var conversions []string
+	for _, source := range sources {
+		conversion, err := getModelPointerCheck(source.Param, source.Model.Type, to.Type, pkg.Path, withError, packages)
+		if err != nil {
+			return models.GeneratedConversionFunction{}, err
+		}
+
+		if conversion != "" {
+			conversions = append(conversions, conversion)
+		}
+	}
+
+	conversions = append(conversions, fillConversions(fields)...)
+
+	if err := checkMergeParamsPackages(sources, packages, pkg.Path); err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	resultStruct, err := createResultConverter(pkg.Path, toName, createModelWithPairs(fields, findHead(fields)))
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	params := make([]string, 0, len(sources))
+	for _, source := range sources {
+		params = append(params, source.Param+" "+source.Model.Type.FullName(pkg.Path))
+	}
+
+	name := generateMergeConvertorName(sources, to.Type, pkg.Path)
+	body, err := fillTemplate[string](mergeConvertorFilePath, map[string]any{
+		"convertorName": name,
+		"fromNames":     strings.Join(mergeSourcesNames(sources, pkg.Path), ", "),
+		"toName":        toName,
+		"toTag":         toTag,
+		"params":        strings.Join(params, ", "),
+		"withError":     withError,
+		"conversions":   conversions,
+		"resultStruct":  resultStruct,
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{
+			Name:      name,
+			Package:   pkg,
+			ToType:    to.Type,
+			TypeParam: models.NoTypeParam,
+			WithError: withError,
+		},
+		Packages: packages,
+		Body:     body,
+	}, nil
+}
+
+// checkMergeParams checks that params of sources are unique identifiers
+func checkMergeParams(sources []Source) error {
+	params := make(map[string]struct{}, len(sources))
+	for _, source := range sources {
+		if !token.IsIdentifier(source.Param) || source.Param == "_" {
+			return fmt.Errorf("%w: %q of %s is not identifier", ErrMergeParam, source.Param, source.Model.Type.Name)
+		}
+
+		if _, ok := params[source.Param]; ok {
+			return fmt.Errorf("%w: %q is used by several sources", ErrMergeParam, source.Param)
+		}
+
+		params[source.Param] = struct{}{}
+	}
+
+	return nil
+}
+
+// checkMergeParamsPackages checks that params do not shadow packages used by convertor
+func checkMergeParamsPackages(sources []Source, packages models.Packages, pkgPath string) error {
+	for pkg := range packages {
+		if pkg.Path == pkgPath {
+			continue
+		}
+
+		name := pkg.Name
+		if pkg.Alias != "" {
+			name = pkg.Alias
+		}
+
+		for _, source := range sources {
+			if source.Param == name {
+				return fmt.Errorf("%w: %q of %s shadows package %s", ErrMergeParam, source.Param,
+					source.Model.Type.Name, pkg.Path)
+			}
+		}
+	}
+
+	return nil
+}
+
+func mergeSourcesNames(sources []Source, pkgPath string) []string {
+	res := make([]string, 0, len(sources))
+	for _, source := range sources {
+		res = append(res, fmt.Sprintf("%s by tag %s", source.Model.Type.FullName(pkgPath), source.Tag))
+	}
+
+	return res
+}
+
+func generateMergeConvertorName(sources []Source, to models.Type, pkgPath string) string {
+	var name strings.Builder
+	name.WriteString("Convert")
+	for _, source := range sources {
+		name.WriteString(structNameGenerator(source.Model.Type, pkgPath))
+	}
+
+	return name.String() + "To" + structNameGenerator(to, pkgPath)
+}
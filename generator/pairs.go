@@ -1,7 +1,9 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/underbek/datamapper/models"
 	"golang.org/x/exp/maps"
@@ -11,35 +13,30 @@ func createModelsPair(from, to models.Struct, pkgPath string, functions models.F
 	var fields []FieldsPair
 	packages := make(models.Packages)
 
-	fromFields := make(map[string]models.Field)
-	from.Fields.Range(func(field models.Field) {
-		fromFields[field.Tags[0].Value] = field
-	})
+	fromFields := fieldsByTag(from)
 
+	// errors of all fields are collected, so all of them can be fixed at once
+	var fieldErrors []*FieldError
 	err := to.Fields.Each(func(field *models.Field) error {
 		fromField, ok := fromFields[field.Tags[0].Value]
-		if !ok {
+		if !ok && !withoutFromField(*field) {
 			//TODO: warning or error politics
 			return nil
 		}
 
-		pair, packs, err := getFieldsPair(fromField, *field, from, to, pkgPath, functions)
+		pair, packs, err := createFieldsPair(fromField, *field, from, to, pkgPath, functions, newPairOptions(to, pkgPath))
 		if err != nil {
-			return err
+			fieldErrors = append(fieldErrors, &FieldError{
+				From:      from.Type,
+				To:        to.Type,
+				FromField: fromField,
+				ToField:   *field,
+				Err:       err,
+			})
+			return nil
 		}
 
-		head := field.Head
-		for head != nil {
-			pair.Types = append([]TypeWithName{{
-				FieldName: head.Name,
-				Type:      head.Type,
-			}}, pair.Types...)
-			packs[head.Type.Package] = struct{}{}
-			head = head.Head
-		}
-		pair.Types = append([]TypeWithName{{Type: to.Type}}, pair.Types...)
 		fields = append(fields, pair)
-
 		maps.Copy(packages, packs)
 
 		return nil
@@ -48,40 +45,19 @@ func createModelsPair(from, to models.Struct, pkgPath string, functions models.F
 		return result{}, err
 	}
 
-	withError := isReturnError(fields)
-
-	var conversions []string
-	if from.Type.Pointer && !to.Type.Pointer {
-		withError = true
+	if len(fieldErrors) != 0 {
+		return result{}, &FieldsError{Errors: fieldErrors}
+	}
 
-		conversion, err := getPointerCheck(
-			"from",
-			to.Type.FullName(pkgPath),
-			fmt.Sprintf("errors.New(\"%s is nil\")", from.Type.Name),
-			withError,
-		)
-		if err != nil {
-			return result{}, err
-		}
+	withError := isReturnError(fields) || from.Type.Pointer && !to.Type.Pointer
 
-		conversions = append(conversions, conversion)
-		packages[models.Package{
-			Name: "errors",
-			Path: "errors",
-		}] = struct{}{}
+	var conversions []string
+	conversion, err := getModelPointerCheck("from", from.Type, to.Type, pkgPath, withError, packages)
+	if err != nil {
+		return result{}, err
 	}
 
-	if from.Type.Pointer && to.Type.Pointer {
-		conversion, err := getPointerCheck(
-			"from",
-			to.Type.FullName(pkgPath),
-			"nil",
-			withError,
-		)
-		if err != nil {
-			return result{}, err
-		}
-
+	if conversion != "" {
 		conversions = append(conversions, conversion)
 	}
 
@@ -95,11 +71,160 @@ func createModelsPair(from, to models.Struct, pkgPath string, functions models.F
 	}, nil
 }
 
+// getModelPointerCheck returns nil check of pointer from model passed by param, it is empty for not pointer model.
+// Nil from model is converted into nil pointer to model or into error
+func getModelPointerCheck(param string, from, to models.Type, pkgPath string, withError bool,
+	packages models.Packages) (string, error) {
+	if !from.Pointer {
+		return "", nil
+	}
+
+	if to.Pointer {
+		return getPointerCheck(param, nilOrDefault(to.FullName(pkgPath)), "nil", withError)
+	}
+
+	packages[models.Package{
+		Name: "errors",
+		Path: "errors",
+	}] = struct{}{}
+
+	return getPointerCheck(
+		param,
+		nilOrDefault(to.FullName(pkgPath)),
+		fmt.Sprintf("errors.New(\"%s is nil\")", from.Name),
+		withError,
+	)
+}
+
+// pairOptions change conversions of fields pair
+type pairOptions struct {
+	// resultModels are models returned with error by failed conversions, only error is returned if they are empty
+	resultModels []string
+	// partial conversions do not check nil pointers of from field, they are skipped before conversions
+	partial bool
+	// fromParam is a name of parameter of from model, it is from if it is empty
+	fromParam string
+	// varPrefix is a prefix of variables of converted fields, it is fromParam if it is empty
+	varPrefix string
+	// pointerParam is set if from model is passed by pointer regardless of its type
+	pointerParam bool
+}
+
+// variable returns name of variable of converted from field
+func (o pairOptions) variable(field models.Field) string {
+	if o.varPrefix == "" {
+		return o.param() + createAssignment(field)
+	}
+
+	return o.varPrefix + createAssignment(field)
+}
+
+// resultValue returns empty values of result models
+func (o pairOptions) resultValue() string {
+	values := make([]string, 0, len(o.resultModels))
+	for _, model := range o.resultModels {
+		values = append(values, nilOrDefault(model))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// param returns name of parameter of from model
+func (o pairOptions) param() string {
+	if o.fromParam == "" {
+		return "from"
+	}
+
+	return o.fromParam
+}
+
+func newPairOptions(toModel models.Struct, pkgPath string) pairOptions {
+	return pairOptions{resultModels: []string{toModel.Type.FullName(pkgPath)}}
+}
+
+// fieldsByTag returns fields of model by values of the mapping tag
+func fieldsByTag(model models.Struct) map[string]models.Field {
+	res := make(map[string]models.Field)
+	model.Fields.Range(func(field models.Field) {
+		res[field.Tags[0].Value] = field
+	})
+
+	return res
+}
+
+// eachFieldsPair calls fn for every pair of fields of models matched by tags in order of to model fields.
+// From field is empty for computed to fields and constants
+func eachFieldsPair(from, to models.Struct, pkgPath string, functions models.Functions, opts pairOptions,
+	fn func(fromField, toField models.Field, pair FieldsPair, packs models.Packages) error) error {
+	fromFields := fieldsByTag(from)
+
+	return to.Fields.Each(func(field *models.Field) error {
+		// partial conversions do not assign computed fields and constants, from model can be filled partially
+		if withoutFromField(*field) && opts.partial {
+			return nil
+		}
+
+		fromField, ok := fromFields[field.Tags[0].Value]
+		if !ok && !withoutFromField(*field) {
+			return nil
+		}
+
+		pair, packs, err := createFieldsPair(fromField, *field, from, to, pkgPath, functions, opts)
+		if err != nil {
+			return err
+		}
+
+		return fn(fromField, *field, pair, packs)
+	})
+}
+
+// createFieldsPair returns pair of fields with types of embedded structs of to field.
+// Computed to field is converted by its function and constant is assigned instead of from field
+func createFieldsPair(from, to models.Field, fromModel, toModel models.Struct, pkgPath string,
+	functions models.Functions, opts pairOptions) (FieldsPair, models.Packages, error) {
+
+	var pair FieldsPair
+	var packs models.Packages
+	var err error
+	switch {
+	case to.Computed != nil:
+		pair, packs, err = getComputedPair(to, fromModel, toModel, pkgPath, opts)
+	case to.Constant != "":
+		pair, packs = getConstantPair(to), make(models.Packages)
+	default:
+		pair, packs, err = getFieldsPair(from, to, fromModel, toModel, pkgPath, functions, opts)
+	}
+	if err != nil {
+		return FieldsPair{}, nil, err
+	}
+
+	head := to.Head
+	for head != nil {
+		pair.Types = append([]TypeWithName{{
+			FieldName: head.Name,
+			Type:      head.Type,
+		}}, pair.Types...)
+		packs[head.Type.Package] = struct{}{}
+		head = head.Head
+	}
+	pair.Types = append([]TypeWithName{{Type: toModel.Type}}, pair.Types...)
+
+	return pair, packs, nil
+}
+
 func getFieldsPair(from, to models.Field, fromModel, toModel models.Struct, pkgPath string, functions models.Functions,
-) (FieldsPair, models.Packages, error) {
+	opts pairOptions) (FieldsPair, models.Packages, error) {
 
 	cf, err := getConversionFunction(from.Type, to.Type, from.Name, functions)
 	if err != nil {
+		var findErr *FindFieldsPairError
+		if errors.As(err, &findErr) {
+			findErr.FromModel = fromModel.Type
+			findErr.ToModel = toModel.Type
+			findErr.FromField = createFieldPath(from)
+			findErr.ToField = createFieldPath(to)
+		}
+
 		return FieldsPair{}, nil, err
 	}
 
@@ -111,7 +236,7 @@ func getFieldsPair(from, to models.Field, fromModel, toModel models.Struct, pkgP
 		WithError: cf.WithError,
 	}
 
-	return fillConversionFunction(res, from, to, fromModel, toModel, cf, pkgPath)
+	return fillConversionFunction(res, from, to, fromModel, toModel, cf, pkgPath, opts)
 }
 
 func getAssigmentBySameTypes(fromFieldFullName string, fromType, toType models.Type) string {
@@ -147,39 +272,25 @@ func createFieldPath(field models.Field) string {
 }
 
 func createFieldPathWithPrefix(field models.Field) string {
-	path := field.Name
-	for field.Head != nil {
-		field = *field.Head
-		path = field.Name + "." + path
-	}
-
-	path = "from." + path
+	return createFieldPathWithParam("from", field)
+}
 
-	return path
+// createFieldPathWithParam returns path of field of model passed by param
+func createFieldPathWithParam(param string, field models.Field) string {
+	return param + "." + createFieldPath(field)
 }
 
+// fillConversionFunction fills pair by conversion function with nil checks of from field.
+// Nil from field is converted into default of to field instead of error if it is set
 func fillConversionFunction(pair FieldsPair, fromField, toField models.Field, fromModel, toModel models.Struct,
-	cf models.ConversionFunction, pkgPath string) (FieldsPair, models.Packages, error) {
+	cf models.ConversionFunction, pkgPath string, opts pairOptions) (FieldsPair, models.Packages, error) {
 	pkgs := make(models.Packages)
-	if cf.Package.Path != "" {
-		pkgs[cf.Package] = struct{}{}
-	}
-
-	cfCall := getConversionFunctionCall(
-		cf,
-		fromField.Type,
-		toField.Type,
-		pkgPath,
-		createFieldPathWithPrefix(fromField),
-	)
-
-	refAssignment := fmt.Sprintf("&from%s", createAssignment(fromField))
-	valueAssignment := fmt.Sprintf("from%s", createAssignment(fromField))
 
-	if isNeedPointerCheckSkippedFields(fromField) {
+	if !opts.partial && isNeedPointerCheckSkippedFields(fromField) {
 		conversions, err := getSkippedFieldsPointerCheckError(
 			fromField,
-			toModel.Type.FullName(pkgPath),
+			opts.param(),
+			opts.resultValue(),
 			fromModel.Type.Name,
 		)
 		if err != nil {
@@ -195,10 +306,12 @@ func fillConversionFunction(pair FieldsPair, fromField, toField models.Field, fr
 		pair.Conversions = append(pair.Conversions, conversions...)
 	}
 
-	if isNeedPointerCheckAndReturnError(fromField.Type, toField.Type, cf) {
+	needPointerCheck := !opts.partial && isNeedPointerCheckAndReturnError(fromField.Type, toField.Type, cf)
+	withDefault := needPointerCheck && toField.Default != ""
+	if needPointerCheck && !withDefault {
 		conversion, err := getPointerCheck(
-			createFieldPathWithPrefix(fromField),
-			toModel.Type.FullName(pkgPath),
+			createFieldPathWithParam(opts.param(), fromField),
+			opts.resultValue(),
 			getFieldPointerCheckError(
 				fromModel.Type.FullName(pkgPath),
 				toModel.Type.FullName(pkgPath),
@@ -220,10 +333,51 @@ func fillConversionFunction(pair FieldsPair, fromField, toField models.Field, fr
 		pair.Conversions = append(pair.Conversions, conversion)
 	}
 
+	checks := pair.Conversions
+	pair.Conversions = nil
+
+	pair, rulePkgs, err := fillConversionRule(pair, fromField, toField, fromModel, toModel, cf, pkgPath, opts)
+	if err != nil {
+		return FieldsPair{}, nil, err
+	}
+	maps.Copy(pkgs, rulePkgs)
+
+	if withDefault {
+		pair, err = getDefaultPair(pair, fromField, toField, pkgPath, opts)
+		if err != nil {
+			return FieldsPair{}, nil, err
+		}
+
+		TypePackages(toField.Type, pkgs)
+	}
+
+	pair.Conversions = append(checks, pair.Conversions...)
+	return pair, pkgs, nil
+}
+
+// fillConversionRule fills conversions and assignment of pair by conversion rule of fields
+func fillConversionRule(pair FieldsPair, fromField, toField models.Field, fromModel, toModel models.Struct,
+	cf models.ConversionFunction, pkgPath string, opts pairOptions) (FieldsPair, models.Packages, error) {
+	pkgs := make(models.Packages)
+	if cf.Package.Path != "" {
+		pkgs[cf.Package] = struct{}{}
+	}
+
+	cfCall := getConversionFunctionCall(
+		cf,
+		fromField.Type,
+		toField.Type,
+		pkgPath,
+		createFieldPathWithParam(opts.param(), fromField),
+	)
+
+	refAssignment := "&" + opts.variable(fromField)
+	valueAssignment := opts.variable(fromField)
+
 	switch getConversionRule(fromField.Type, toField.Type, cf) {
 	case NeedOnlyAssigmentRule:
 		pair.Assignment = getAssigmentBySameTypes(
-			createFieldPathWithPrefix(fromField),
+			createFieldPathWithParam(opts.param(), fromField),
 			fromField.Type,
 			toField.Type,
 		)
@@ -264,8 +418,8 @@ func fillConversionFunction(pair FieldsPair, fromField, toField models.Field, fr
 
 		conversion, err := getPointerToPointerConversion(
 			valueAssignment,
-			createFieldPathWithPrefix(fromField),
-			toModel.Type.FullName(pkgPath),
+			createFieldPathWithParam(opts.param(), fromField),
+			opts.resultValue(),
 			toField.Type.FullName(pkgPath),
 			cfCall,
 			errString,
@@ -300,7 +454,7 @@ func fillConversionFunction(pair FieldsPair, fromField, toField models.Field, fr
 
 		conversion, err := getErrorConversion(
 			valueAssignment,
-			toModel.Type.FullName(pkgPath),
+			opts.resultValue(),
 			cfCall,
 			errString,
 		)
@@ -315,14 +469,26 @@ func fillConversionFunction(pair FieldsPair, fromField, toField models.Field, fr
 			pair.Assignment = refAssignment
 		}
 		return pair, pkgs, nil
-	case NeedRangeBySlice:
-		resPair, resPkgs, err := fillConversionFunctionBySlice(pair, fromField, toField, fromModel, toModel, cf, pkgPath)
+	case NeedRangeBySlice, NeedRangeByArray, NeedRangeByMap:
+		resPair, resPkgs, err := fillConversionFunctionByContainer(
+			pair,
+			fromField,
+			toField,
+			fromModel,
+			toModel,
+			cf,
+			pkgPath,
+			opts,
+		)
 		if err != nil {
 			return FieldsPair{}, nil, err
 		}
 		maps.Copy(pkgs, resPkgs)
 
 		resPair.Assignment = valueAssignment
+		if toField.Type.Pointer && !fromField.Type.Pointer {
+			resPair.Assignment = refAssignment
+		}
 
 		return resPair, pkgs, nil
 	}
@@ -335,22 +501,25 @@ func fillConversionFunction(pair FieldsPair, fromField, toField models.Field, fr
 	)
 }
 
-func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Field, fromModel, toModel models.Struct,
-	cf models.ConversionFunction, pkgPath string) (FieldsPair, models.Packages, error) {
+func fillConversionFunctionByContainer(pair FieldsPair, fromField, toField models.Field, fromModel, toModel models.Struct,
+	cf models.ConversionFunction, pkgPath string, opts pairOptions) (FieldsPair, models.Packages, error) {
 
 	pkgs := make(models.Packages)
 
+	fromItemType := getItemType(fromField.Type)
+	toItemType := getItemType(toField.Type)
+
 	cfCall := getConversionFunctionCall(
 		cf,
-		fromField.Type.Additional.(models.SliceAdditional).InType,
-		toField.Type.Additional.(models.SliceAdditional).InType,
+		fromItemType,
+		toItemType,
 		pkgPath,
 		"item",
 	)
 
 	rule := getConversionRule(
-		fromField.Type.Additional.(models.SliceAdditional).InType,
-		toField.Type.Additional.(models.SliceAdditional).InType,
+		fromItemType,
+		toItemType,
 		cf,
 	)
 
@@ -361,13 +530,13 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 	var assigment string
 
 	if isNeedPointerCheckAndReturnError(
-		fromField.Type.Additional.(models.SliceAdditional).InType,
-		toField.Type.Additional.(models.SliceAdditional).InType,
+		fromItemType,
+		toItemType,
 		cf,
 	) {
 		conversion, err := getPointerCheck(
 			"item",
-			toModel.Type.FullName(pkgPath),
+			opts.resultValue(),
 			getFieldPointerCheckError(
 				fromModel.Type.FullName(pkgPath),
 				toModel.Type.FullName(pkgPath),
@@ -392,8 +561,8 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 	switch rule {
 	case NeedOnlyAssigmentRule:
 		fromFieldFullName := "item"
-		if !fromField.Type.Additional.(models.SliceAdditional).InType.Pointer &&
-			toField.Type.Additional.(models.SliceAdditional).InType.Pointer {
+		if !fromItemType.Pointer &&
+			toItemType.Pointer {
 
 			// cannot use reference by range element
 			conversions = append(conversions, "res := item")
@@ -402,8 +571,8 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 
 		assigment = getAssigmentBySameTypes(
 			fromFieldFullName,
-			fromField.Type.Additional.(models.SliceAdditional).InType,
-			toField.Type.Additional.(models.SliceAdditional).InType,
+			fromItemType,
+			toItemType,
 		)
 
 	case NeedCallConversionFunctionRule:
@@ -427,7 +596,7 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 
 		conversion, err := getErrorConversion(
 			"res",
-			toModel.Type.FullName(pkgPath),
+			opts.resultValue(),
 			cfCall,
 			errString,
 		)
@@ -438,7 +607,7 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 		conversions = append(conversions, conversion)
 		assigment = valueAssignment
 		pair.WithError = true
-		if toField.Type.Additional.(models.SliceAdditional).InType.Pointer && !cf.ToType.Pointer {
+		if toItemType.Pointer && !cf.ToType.Pointer {
 			assigment = refAssignment
 		}
 
@@ -472,8 +641,8 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 		conversion, err := getPointerToPointerConversion(
 			"resPtr",
 			"item",
-			toModel.Type.FullName(pkgPath),
-			toField.Type.Additional.(models.SliceAdditional).InType.FullName(pkgPath),
+			opts.resultValue(),
+			toItemType.FullName(pkgPath),
 			cfCall,
 			errString,
 			cf.WithError,
@@ -495,10 +664,12 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 		)
 	}
 
-	conversion, err := getSliceConversion(
-		fmt.Sprintf("from%s", createAssignment(fromField)),
-		createFieldPathWithPrefix(fromField),
-		toField.Type.Additional.(models.SliceAdditional).InType.FullName(pkgPath),
+	conversion, err := getContainerConversion(
+		opts.variable(fromField),
+		createFieldPathWithParam(opts.param(), fromField),
+		fromField.Type,
+		toField.Type,
+		pkgPath,
 		assigment,
 		conversions,
 	)
@@ -507,7 +678,65 @@ func fillConversionFunctionBySlice(pair FieldsPair, fromField, toField models.Fi
 	}
 
 	pair.Conversions = append(pair.Conversions, conversion)
-	pkgs[toField.Type.Additional.(models.SliceAdditional).InType.Package] = struct{}{}
+	pkgs[toItemType.Package] = struct{}{}
+	if toField.Type.Kind == models.MapType {
+		pkgs[toField.Type.Additional.(models.MapAdditional).KeyType.Package] = struct{}{}
+	}
 
 	return pair, pkgs, nil
 }
+
+func getContainerConversion(fromFieldFullName, fromFieldPath string, fromType, toType models.Type, pkgPath,
+	assigment string, conversions []string) (string, error) {
+	if !fromType.Pointer {
+		return getRangeConversion(fromFieldFullName, fromFieldPath, toType, pkgPath, assigment, conversions)
+	}
+
+	// nil pointer is checked before conversion if to field is not pointer
+	if !toType.Pointer {
+		return getRangeConversion(fromFieldFullName, "*"+fromFieldPath, toType, pkgPath, assigment, conversions)
+	}
+
+	valueName := fromFieldFullName + "Value"
+	conversion, err := getRangeConversion(valueName, "*"+fromFieldPath, toType, pkgPath, assigment, conversions)
+	if err != nil {
+		return "", err
+	}
+
+	return getPointerContainerConversion(
+		fromFieldFullName,
+		fromFieldPath,
+		getFullTypeName(toType, pkgPath),
+		conversion,
+		"&"+valueName,
+	)
+}
+
+func getRangeConversion(fromFieldFullName, fromFieldPath string, toType models.Type, pkgPath, assigment string,
+	conversions []string) (string, error) {
+
+	toItemTypeName := getFullTypeName(getItemType(toType), pkgPath)
+
+	switch additional := toType.Additional.(type) {
+	case models.ArrayAdditional:
+		return getArrayConversion(
+			fromFieldFullName,
+			fromFieldPath,
+			toItemTypeName,
+			additional.Len,
+			assigment,
+			conversions,
+		)
+	case models.MapAdditional:
+		return getMapConversion(
+			fromFieldFullName,
+			fromFieldPath,
+			getFullTypeName(additional.KeyType, pkgPath),
+			toItemTypeName,
+			assigment,
+			conversions,
+		)
+	default:
+		return getSliceConversion(fromFieldFullName, fromFieldPath, toItemTypeName, assigment, conversions)
+	}
+}
@@ -0,0 +1,215 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/underbek/datamapper/models"
+	"golang.org/x/tools/imports"
+)
+
+const (
+	roundTripTestFilePath = "templates/round_trip_test.temp"
+	testSourceFilePath    = "templates/test_source.temp"
+
+	convertsPackagePath = "github.com/underbek/datamapper/converts"
+)
+
+// lossyFunctions are internal conversion functions which do not restore all values by inverse conversion
+var lossyFunctions = map[string]struct{}{
+	convertsPackagePath + ".ConvertDecimalToNumeric": {},
+	convertsPackagePath + ".ConvertOrderedToOrdered": {},
+	convertsPackagePath + ".ConvertFloatToDecimal":   {},
+	convertsPackagePath + ".ConvertComplexToString":  {},
+}
+
+// fuzzTypes are types of fields which are supported by testing.F with zero and seed values
+var fuzzTypes = map[string]string{
+	"string":  `"datamapper"`,
+	"bool":    "true",
+	"int":     "1",
+	"int8":    "1",
+	"int16":   "1",
+	"int32":   "1",
+	"int64":   "1",
+	"uint":    "1",
+	"uint8":   "1",
+	"uint16":  "1",
+	"uint32":  "1",
+	"uint64":  "1",
+	"float32": "1.5",
+	"float64": "1.5",
+	"byte":    "1",
+	"rune":    "1",
+}
+
+// RoundTripField is a field of source model which is checked by round-trip test
+type RoundTripField struct {
+	Name  string
+	Param string
+	Type  string
+	Zero  string
+	Seed  string
+	Float bool
+}
+
+// IsLossyFunction checks that conversion function is a known lossy internal function
+// or one of lossy functions by name or by {package path}.{name}
+func IsLossyFunction(cf models.ConversionFunction, lossy []string) bool {
+	if cf.Name == "" {
+		return false
+	}
+
+	fullName := cf.Package.Path + "." + cf.Name
+	if _, ok := lossyFunctions[fullName]; ok {
+		return true
+	}
+
+	for _, name := range lossy {
+		if name == cf.Name || name == fullName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GenerateRoundTripTest returns fuzz test which converts from model to model and back and compares fields.
+// Only fields of fuzzing types which are converted in both directions without lossy functions are checked,
+// test is empty if there are no such fields. Convertors of both directions must be in functions
+func GenerateRoundTripTest(from, to models.Struct, pkg models.Package, functions models.Functions, lossy []string,
+) (models.GeneratedConversionFunction, error) {
+	forward, ok := functions[models.ConversionFunctionKey{FromType: from.Type, ToType: to.Type}]
+	if !ok {
+		return models.GeneratedConversionFunction{}, fmt.Errorf("%w: convertor %s -> %s", ErrNotFound, from.Type.Name, to.Type.Name)
+	}
+
+	inverse, ok := functions[models.ConversionFunctionKey{FromType: to.Type, ToType: from.Type}]
+	if !ok {
+		return models.GeneratedConversionFunction{}, fmt.Errorf("%w: convertor %s -> %s", ErrNotFound, to.Type.Name, from.Type.Name)
+	}
+
+	fields := roundTripFields(from, to, functions, lossy)
+	if len(fields) == 0 {
+		return models.GeneratedConversionFunction{}, nil
+	}
+
+	fromType := from.Type
+	fromType.Pointer = false
+
+	name := fmt.Sprintf("Fuzz%sRoundTrip", forward.Name)
+	body, err := fillTemplate[string](roundTripTestFilePath, map[string]any{
+		"name":             name,
+		"fromName":         fromType.FullName(pkg.Path),
+		"fromPointer":      from.Type.Pointer,
+		"convertorName":    getConversionFunctionName(forward, pkg.Path),
+		"withError":        forward.WithError,
+		"inverseName":      getConversionFunctionName(inverse, pkg.Path),
+		"inverseWithError": inverse.WithError,
+		"fields":           fields,
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	packages := models.Packages{
+		from.Type.Package:                  {},
+		to.Type.Package:                    {},
+		{Name: "testing", Path: "testing"}: {},
+	}
+
+	for _, field := range fields {
+		if field.Float {
+			packages[models.Package{Name: "math", Path: "math"}] = struct{}{}
+		}
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{Name: name, Package: pkg},
+		Packages: packages,
+		Body:     body,
+	}, nil
+}
+
+// GenerateTestSource returns formatted content of tests source
+func GenerateTestSource(pkg models.Package, packages models.Packages, tests []string) ([]byte, error) {
+	imps := make([]string, 0, len(packages))
+	for pkg := range packages {
+		imps = append(imps, pkg.Import())
+	}
+
+	body, err := fillTemplate[[]byte](testSourceFilePath, map[string]any{
+		"packageName": pkg.Name,
+		"imports":     filterAndSortImports(pkg.Import(), imps),
+		"tests":       tests,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return imports.Process(pkg.Path, body, nil)
+}
+
+func roundTripFields(from, to models.Struct, functions models.Functions, lossy []string) []RoundTripField {
+	toFields := make(map[string]models.Field)
+	to.Fields.Range(func(field models.Field) {
+		toFields[field.Tags[0].Value] = field
+	})
+
+	var res []RoundTripField
+	from.Fields.Range(func(field models.Field) {
+		seed, ok := fuzzTypes[field.Type.Name]
+		if !ok || field.Head != nil || field.Type.Pointer || field.Type.Package.Path != "" ||
+			field.Type.Kind != models.BaseType {
+			return
+		}
+
+		toField, ok := toFields[field.Tags[0].Value]
+		if !ok || withoutFromField(field) || withoutFromField(toField) {
+			return
+		}
+
+		forward, err := getConversionFunction(field.Type, toField.Type, field.Name, functions)
+		if err != nil || IsLossyFunction(forward, lossy) {
+			return
+		}
+
+		inverse, err := getConversionFunction(toField.Type, field.Type, toField.Name, functions)
+		if err != nil || IsLossyFunction(inverse, lossy) {
+			return
+		}
+
+		zero := "0"
+		switch field.Type.Name {
+		case "string":
+			zero = `""`
+		case "bool":
+			zero = "false"
+		}
+
+		res = append(res, RoundTripField{
+			Name:  field.Name,
+			Param: "field" + field.Name,
+			Type:  field.Type.Name,
+			Zero:  zero,
+			Seed:  seed,
+			Float: strings.HasPrefix(field.Type.Name, "float"),
+		})
+	})
+
+	return res
+}
+
+// getConversionFunctionName returns name of conversion function called from package
+func getConversionFunctionName(cf models.ConversionFunction, pkgPath string) string {
+	if cf.Package.Path == pkgPath || cf.Package.Name == "" {
+		return cf.Name
+	}
+
+	name := cf.Package.Name
+	if cf.Package.Alias != "" {
+		name = cf.Package.Alias
+	}
+
+	return name + "." + cf.Name
+}
@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/underbek/datamapper/models"
+	"golang.org/x/exp/maps"
+)
+
+const splitConvertorFilePath = "templates/split_convertor.temp"
+
+// Target is a to model of split convertor
+type Target struct {
+	Model models.Struct
+	Tag   string
+}
+
+// GenerateSplitConvertor returns convertor of from model into several to models which are returned in order
+// of targets. Fields of every to model are matched with fields of from model by tag of the to model
+func GenerateSplitConvertor(from models.Struct, fromTag string, targets []Target, pkg models.Package,
+	functions models.Functions) (models.GeneratedConversionFunction, error) {
+
+	packages := models.Packages{from.Type.Package: {}}
+	resultModels := make([]string, 0, len(targets))
+	for _, target := range targets {
+		packages[target.Model.Type.Package] = struct{}{}
+		resultModels = append(resultModels, target.Model.Type.FullName(pkg.Path))
+	}
+
+	fromFields := fieldsByTag(from)
+	targetsFields := make([][]FieldsPair, len(targets))
+
+	var fieldErrors []*FieldError
+	for i, target := range targets {
+		// variables of fields are named by to model, so fields converted into several models do not conflict
+		opts := pairOptions{resultModels: resultModels, varPrefix: "from" + structNameGenerator(target.Model.Type, pkg.Path)}
+
+		_ = target.Model.Fields.Each(func(field *models.Field) error {
+			fromField, ok := fromFields[field.Tags[0].Value]
+			if !ok {
+				return nil
+			}
+
+			pair, packs, err := createFieldsPair(fromField, *field, from, target.Model, pkg.Path, functions, opts)
+			if err != nil {
+				fieldErrors = append(fieldErrors, &FieldError{
+					From:      from.Type,
+					To:        target.Model.Type,
+					FromField: fromField,
+					ToField:   *field,
+					Err:       err,
+				})
+				return nil
+			}
+
+			targetsFields[i] = append(targetsFields[i], pair)
+			maps.Copy(packages, packs)
+			return nil
+		})
+	}
+
+	if len(fieldErrors) != 0 {
+		return models.GeneratedConversionFunction{}, &FieldsError{Errors: fieldErrors}
+	}
+
+	withError := from.Type.Pointer
+	var fields []FieldsPair
+	for i, target := range targets {
+		if len(targetsFields[i]) == 0 {
+			return models.GeneratedConversionFunction{}, fmt.Errorf(
+				"%w %s by tag %s -> %s by tag %s",
+				ErrNothingToConvert,
+				from.Type.Name,
+				fromTag,
+				target.Model.Type.Name,
+				target.Tag,
+			)
+		}
+
+		withError = withError || isReturnError(targetsFields[i])
+		fields = append(fields, targetsFields[i]...)
+	}
+
+	var conversions []string
+	if from.Type.Pointer {
+		conversion, err := getPointerCheck(
+			"from",
+			pairOptions{resultModels: resultModels}.resultValue(),
+			fmt.Sprintf("errors.New(\"%s is nil\")", from.Type.Name),
+			true,
+		)
+		if err != nil {
+			return models.GeneratedConversionFunction{}, err
+		}
+
+		conversions = append(conversions, conversion)
+		packages[models.Package{Name: "errors", Path: "errors"}] = struct{}{}
+	}
+
+	conversions = append(conversions, fillConversions(fields)...)
+
+	resultStructs := make([]string, 0, len(targets))
+	toNames := make([]string, 0, len(targets))
+	for i, target := range targets {
+		resultStruct, err := createResultConverter(
+			pkg.Path,
+			resultModels[i],
+			createModelWithPairs(targetsFields[i], findHead(targetsFields[i])),
+		)
+		if err != nil {
+			return models.GeneratedConversionFunction{}, err
+		}
+
+		resultStructs = append(resultStructs, resultStruct)
+		toNames = append(toNames, fmt.Sprintf("%s by tag %s", resultModels[i], target.Tag))
+	}
+
+	name := generateSplitConvertorName(from.Type, targets, pkg.Path)
+	body, err := fillTemplate[string](splitConvertorFilePath, map[string]any{
+		"convertorName": name,
+		"fromName":      from.Type.FullName(pkg.Path),
+		"fromTag":       fromTag,
+		"toNames":       strings.Join(toNames, ", "),
+		"results":       strings.Join(resultModels, ", "),
+		"resultStructs": strings.Join(resultStructs, ", "),
+		"withError":     withError,
+		"conversions":   conversions,
+	})
+	if err != nil {
+		return models.GeneratedConversionFunction{}, err
+	}
+
+	return models.GeneratedConversionFunction{
+		Function: models.ConversionFunction{
+			Name:      name,
+			Package:   pkg,
+			FromType:  from.Type,
+			TypeParam: models.NoTypeParam,
+			WithError: withError,
+		},
+		Packages: packages,
+		Body:     body,
+	}, nil
+}
+
+// NotConsumedFields returns fields of from model which tags do not match fields of any of to models
+func NotConsumedFields(from models.Struct, targets []Target) []models.Field {
+	consumed := make(map[string]struct{})
+	for _, target := range targets {
+		target.Model.Fields.Range(func(field models.Field) {
+			consumed[field.Tags[0].Value] = struct{}{}
+		})
+	}
+
+	var res []models.Field
+	from.Fields.Range(func(field models.Field) {
+		if _, ok := consumed[field.Tags[0].Value]; !ok {
+			res = append(res, field)
+		}
+	})
+
+	return res
+}
+
+func generateSplitConvertorName(from models.Type, targets []Target, pkgPath string) string {
+	var name strings.Builder
+	name.WriteString("Convert" + structNameGenerator(from, pkgPath) + "To")
+	for _, target := range targets {
+		name.WriteString(structNameGenerator(target.Model.Type, pkgPath))
+	}
+
+	return name.String()
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/underbek/datamapper/models"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/imports"
+)
+
+const (
+	stubFilePath        = "templates/stub.temp"
+	stubsSourceFilePath = "templates/stubs_source.temp"
+)
+
+// StubFunction returns conversion function of types which is matched by generator if it is written into pkg
+func StubFunction(from, to models.Type, pkg models.Package) models.ConversionFunction {
+	return models.ConversionFunction{
+		Name:     fmt.Sprintf("Convert%sTo%s", stubTypeName(from, pkg.Path), stubTypeName(to, pkg.Path)),
+		Package:  pkg,
+		FromType: from,
+		ToType:   to,
+	}
+}
+
+// GenerateStubsSource returns current source of pkg with appended stubs of conversion functions.
+// Functions which are already declared in current source are skipped, new source is created if current is nil
+func GenerateStubsSource(pkg models.Package, current []byte, cfs []models.ConversionFunction) ([]byte, error) {
+	if current == nil {
+		source, err := fillTemplate[[]byte](stubsSourceFilePath, map[string]any{"packageName": pkg.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		current = source
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", current, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse stubs source error: %w", err)
+	}
+
+	declared := make(map[string]struct{})
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+			declared[fn.Name.Name] = struct{}{}
+		}
+	}
+
+	packages := make(models.Packages)
+	stubs := make([]string, 0, len(cfs))
+	for _, cf := range cfs {
+		if _, ok := declared[cf.Name]; ok {
+			continue
+		}
+		declared[cf.Name] = struct{}{}
+
+		stub, err := fillTemplate[string](stubFilePath, map[string]any{
+			"name":     cf.Name,
+			"fromName": getFullTypeName(cf.FromType, pkg.Path),
+			"toName":   getFullTypeName(cf.ToType, pkg.Path),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		stubs = append(stubs, stub)
+		TypePackages(cf.FromType, packages)
+		TypePackages(cf.ToType, packages)
+	}
+
+	imps := make([]models.Package, 0, len(packages))
+	for imp := range packages {
+		if imp.Path != "" && imp.Path != pkg.Path {
+			imps = append(imps, imp)
+		}
+	}
+
+	sort.Slice(imps, func(i, j int) bool {
+		return imps[i].Path < imps[j].Path
+	})
+
+	for _, imp := range imps {
+		astutil.AddNamedImport(fset, file, imp.Alias, imp.Path)
+	}
+
+	buf := bytes.Buffer{}
+	if err = printer.Fprint(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("print stubs source error: %w", err)
+	}
+
+	for _, stub := range stubs {
+		buf.WriteString("\n" + stub)
+	}
+
+	return imports.Process(pkg.Path, buf.Bytes(), nil)
+}
+
+// stubTypeName returns part of stub name by type: package name, type name, containers and pointer
+func stubTypeName(t models.Type, pkgPath string) string {
+	var name string
+	switch additional := t.Additional.(type) {
+	case models.SliceAdditional:
+		name = stubTypeName(additional.InType, pkgPath) + "Slice"
+	case models.ArrayAdditional:
+		name = fmt.Sprintf("%sArray%d", stubTypeName(additional.InType, pkgPath), additional.Len)
+	case models.MapAdditional:
+		name = "Map" + stubTypeName(additional.KeyType, pkgPath) + stubTypeName(additional.ValueType, pkgPath)
+	default:
+		name = t.Name
+		if t.Package.Path != "" && t.Package.Path != pkgPath {
+			pkgName := t.Package.Name
+			if t.Package.Alias != "" {
+				pkgName = t.Package.Alias
+			}
+
+			name = cases.Title(language.Und, cases.NoLower).String(pkgName) + name
+		}
+	}
+
+	if t.Pointer {
+		name += "Ptr"
+	}
+
+	// type arguments of generic types are not valid in names
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, name)
+
+	return cases.Title(language.Und, cases.NoLower).String(name)
+}
+
+// TypePackages adds packages of type and its items to packages
+func TypePackages(t models.Type, packages models.Packages) {
+	packages[t.Package] = struct{}{}
+
+	switch additional := t.Additional.(type) {
+	case models.SliceAdditional:
+		TypePackages(additional.InType, packages)
+	case models.ArrayAdditional:
+		TypePackages(additional.InType, packages)
+	case models.MapAdditional:
+		TypePackages(additional.KeyType, packages)
+		TypePackages(additional.ValueType, packages)
+	}
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"github.com/underbek/datamapper/models"
+)
+
+const defaultConversionFilePath = "templates/default_conversion.temp"
+
+// withoutFromField checks that to field is not converted from field of from model
+func withoutFromField(field models.Field) bool {
+	return field.Computed != nil || field.Constant != ""
+}
+
+// getConstantPair returns pair of to field which is assigned by constant
+func getConstantPair(to models.Field) FieldsPair {
+	return FieldsPair{
+		ToName:     to.Name,
+		ToType:     to.Type.Name,
+		Assignment: to.Constant,
+	}
+}
+
+// getDefaultPair returns pair which assigns default of to field if from field is nil pointer
+// and converts from field otherwise
+func getDefaultPair(pair FieldsPair, fromField, toField models.Field, pkgPath string, opts pairOptions,
+) (FieldsPair, error) {
+	variable := "default" + createAssignment(toField)
+	conversion, err := fillTemplate[string](defaultConversionFilePath, map[string]any{
+		"variable":      variable,
+		"toType":        getFullTypeName(toField.Type, pkgPath),
+		"fromFieldPath": createFieldPathWithParam(opts.param(), fromField),
+		"value":         toField.Default,
+		"conversions":   pair.Conversions,
+		"assignment":    pair.Assignment,
+	})
+	if err != nil {
+		return FieldsPair{}, err
+	}
+
+	pair.Conversions = []string{conversion}
+	pair.Assignment = variable
+
+	return pair, nil
+}
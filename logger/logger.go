@@ -61,3 +61,18 @@ func (l *logger) Fatalf(format string, v ...any) {
 	_ = l.error.Output(depth, fmt.Sprintf(format, v...))
 	os.Exit(1)
 }
+
+type nopLogger struct{}
+
+// NewNop returns logger which discards all messages and never exits
+func NewNop() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Info(...any)           {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Warn(...any)           {}
+func (nopLogger) Error(...any)          {}
+func (nopLogger) Errorf(string, ...any) {}
+func (nopLogger) Fatal(...any)          {}
+func (nopLogger) Fatalf(string, ...any) {}
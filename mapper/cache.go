@@ -0,0 +1,62 @@
+package mapper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/underbek/datamapper/diskcache"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// openDiskCache returns nil if cache is disabled or cannot be opened
+func openDiskCache(lg logger.Logger, opts options.Options) *diskcache.Cache {
+	if opts.NoCache || opts.CacheDir == "" {
+		return nil
+	}
+
+	disk, err := diskcache.New(opts.CacheDir)
+	if err != nil {
+		lg.Warn(fmt.Errorf("open cache error: %w", err))
+		return nil
+	}
+
+	return disk
+}
+
+func newSession(ctx context.Context, disk *diskcache.Cache) *parser.Session {
+	session := parser.NewSession(ctx)
+	if disk != nil {
+		session.SetDiskCache(disk)
+	}
+
+	return session
+}
+
+// runKey returns key of options which change generated sources.
+// Runs with stdout destination are not skipped, so they have not key
+func runKey(opts options.Options) string {
+	for _, opt := range opts.Options {
+		if opt.Destination == options.StdoutDestination {
+			return ""
+		}
+	}
+
+	for _, selector := range opts.Selectors {
+		if selector.Destination == options.StdoutDestination {
+			return ""
+		}
+	}
+
+	opts.Parallel = 0
+	opts.CacheDir = ""
+
+	config, err := yaml.Marshal(opts)
+	if err != nil {
+		return ""
+	}
+
+	return diskcache.RunKey(config)
+}
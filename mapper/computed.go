@@ -0,0 +1,123 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/underbek/datamapper/generator"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+)
+
+// computeOption is an option of mapping tag which binds field to computed function like `map:"name,compute=Name"`
+const computeOption = "compute"
+
+var ErrNotFoundComputed = errors.New("not found computed function")
+
+// parseComputedFunctions returns computed functions of all sources in order of sources.
+// Aliases of packages of sources are added into cfAliases
+func parseComputedFunctions(
+	lg logger.Logger,
+	session *parser.Session,
+	sources []options.ConversionFunction,
+	cfAliases map[string]string,
+) ([]models.ConversionFunction, error) {
+	var res []models.ConversionFunction
+	for _, source := range sources {
+		funcs, err := session.ParseComputedFunctionsByPackage(lg, source.Source)
+		if err != nil {
+			return nil, fmt.Errorf("parse computed functions error: %w", err)
+		}
+
+		for _, function := range funcs {
+			cfAliases[function.Package.Path] = source.Alias
+		}
+
+		res = append(res, funcs...)
+	}
+
+	return res, nil
+}
+
+// excludeComputed returns conversion functions without computed functions
+func excludeComputed(funcs models.Functions, computed []models.ConversionFunction) models.Functions {
+	names := make(map[string]struct{}, len(computed))
+	for _, cf := range computed {
+		names[cf.Package.Path+"."+cf.Name] = struct{}{}
+	}
+
+	res := make(models.Functions, len(funcs))
+	for key, function := range funcs {
+		if _, ok := names[function.Package.Path+"."+function.Name]; !ok {
+			res[key] = function
+		}
+	}
+
+	return res
+}
+
+// bindComputed sets computed functions of from model into bound fields of model.
+// Bindings of option override compute options of tags of fields of the root to model
+func (m *modelMapper) bindComputed(model *models.Struct, from models.Type) error {
+	return model.Fields.Each(func(field *models.Field) error {
+		field.Computed = nil
+
+		name, ok := m.fieldBinding(model.Type, *field, m.computedFields, computeOption)
+		if !ok {
+			return nil
+		}
+
+		cf, err := m.findComputed(name, from, model.Type, *field)
+		if err != nil {
+			return err
+		}
+
+		field.Computed = &cf
+		return nil
+	})
+}
+
+// findComputed returns computed function by name which takes from model and returns type of field
+func (m *modelMapper) findComputed(name string, from, model models.Type, field models.Field,
+) (models.ConversionFunction, error) {
+	for _, cf := range m.computed {
+		if cf.Name != name && cf.Package.Path+"."+cf.Name != name {
+			continue
+		}
+
+		if !sameModel(cf.FromType, from) {
+			continue
+		}
+
+		cf = setPackageAliasToCf(cf, m.aliases)
+		if cf.ToType != field.Type {
+			return models.ConversionFunction{}, fmt.Errorf(
+				"%w: %s returns %s, field %s.%s has type %s",
+				ErrNotFoundComputed,
+				name,
+				generator.TypeName(cf.ToType),
+				model.Name,
+				fullFieldNameForSkipComment(field),
+				generator.TypeName(field.Type),
+			)
+		}
+
+		return cf, nil
+	}
+
+	return models.ConversionFunction{}, fmt.Errorf(
+		"%w: %s of %s for field %s.%s",
+		ErrNotFoundComputed,
+		name,
+		from.Name,
+		model.Name,
+		fullFieldNameForSkipComment(field),
+	)
+}
+
+// sameModel checks that types are the same struct regardless of pointers and aliases
+func sameModel(a, b models.Type) bool {
+	return a.Name == b.Name && a.Package.Path == b.Package.Path
+}
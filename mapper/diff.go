@@ -0,0 +1,180 @@
+package mapper
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns unified diff of two contents or empty string if they are equal
+func unifiedDiff(oldName, newName string, oldContent, newContent []byte) string {
+	if string(oldContent) == string(newContent) {
+		return ""
+	}
+
+	ops := diffLines(splitLines(oldContent), splitLines(newContent))
+
+	buf := strings.Builder{}
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldName, newName)
+
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			continue
+		}
+
+		// hunk starts with context before the first change
+		from := start - diffContext
+		if from < 0 {
+			from = 0
+		}
+
+		// hunk ends when there are more than two contexts of equal lines after a change
+		to := start
+		for equal := 0; to < len(ops) && equal <= 2*diffContext; to++ {
+			if ops[to].kind == ' ' {
+				equal++
+			} else {
+				equal = 0
+			}
+		}
+
+		for to > start && ops[to-1].kind == ' ' {
+			to--
+		}
+
+		to += diffContext
+		if to > len(ops) {
+			to = len(ops)
+		}
+
+		writeHunk(&buf, ops, from, to)
+		start = to
+	}
+
+	return buf.String()
+}
+
+func writeHunk(buf *strings.Builder, ops []diffOp, from, to int) {
+	oldStart, newStart := 1, 1
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			oldStart++
+		}
+		if op.kind != '-' {
+			newStart++
+		}
+	}
+
+	oldLen, newLen := 0, 0
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			oldLen++
+		}
+		if op.kind != '-' {
+			newLen++
+		}
+	}
+
+	if oldLen == 0 {
+		oldStart--
+	}
+
+	if newLen == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", oldStart, oldLen, newStart, newLen)
+	for _, op := range ops[from:to] {
+		fmt.Fprintf(buf, "%c%s\n", op.kind, op.line)
+	}
+}
+
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+// diffLines returns edit script of lines. Common prefix and suffix are trimmed before search
+// of the longest common subsequence, so table of subsequences covers only the changed part
+func diffLines(oldLines, newLines []string) []diffOp {
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	ops := make([]diffOp, 0, len(oldLines)+len(newLines))
+	for _, line := range oldLines[:prefix] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+
+	ops = append(ops, lcsLines(oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix])...)
+
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+
+	return ops
+}
+
+// lcsLines finds the longest common subsequence of lines and returns edit script
+func lcsLines(oldLines, newLines []string) []diffOp {
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(oldLines)+len(newLines))
+	i, j := 0, 0
+	for i < len(oldLines) && j < len(newLines) {
+		switch {
+		case oldLines[i] == newLines[j]:
+			ops = append(ops, diffOp{kind: ' ', line: oldLines[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{kind: '-', line: oldLines[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: newLines[j]})
+			j++
+		}
+	}
+
+	for ; i < len(oldLines); i++ {
+		ops = append(ops, diffOp{kind: '-', line: oldLines[i]})
+	}
+
+	for ; j < len(newLines); j++ {
+		ops = append(ops, diffOp{kind: '+', line: newLines[j]})
+	}
+
+	return ops
+}
@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_UnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected string
+	}{
+		{
+			name: "equal",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+		},
+		{
+			name:     "new file",
+			new:      "a\nb\n",
+			expected: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name:     "changed line",
+			old:      "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:      "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			expected: "--- old\n+++ new\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n",
+		},
+		{
+			name: "two hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			expected: "--- old\n+++ new\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n" +
+				"@@ -9,4 +10,3 @@\n 9\n 10\n 11\n-12\n",
+		},
+		{
+			name:     "repeated lines",
+			old:      "a\na\na\n",
+			new:      "a\na\n",
+			expected: "--- old\n+++ new\n@@ -1,3 +1,2 @@\n a\n a\n-a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, unifiedDiff("old", "new", []byte(tt.old), []byte(tt.new)))
+		})
+	}
+}
+
+func Test_DiffLinesLargeFile(t *testing.T) {
+	const size = 200000
+
+	oldLines := make([]string, size)
+	for i := range oldLines {
+		oldLines[i] = strconv.Itoa(i)
+	}
+
+	newLines := append([]string(nil), oldLines...)
+	newLines[size/2] = "changed"
+
+	ops := diffLines(oldLines, newLines)
+	require.Len(t, ops, size+1)
+	assert.Equal(t, diffOp{kind: '-', line: strconv.Itoa(size / 2)}, ops[size/2])
+	assert.Equal(t, diffOp{kind: '+', line: "changed"}, ops[size/2+1])
+}
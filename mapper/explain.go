@@ -0,0 +1,220 @@
+package mapper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/underbek/datamapper/generator"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+)
+
+var ErrUnknownFormat = errors.New("unknown format error")
+
+// ExplainModels maps options without writing convertors and prints how every destination field is converted
+func ExplainModels(lg logger.Logger, opts options.Options, out io.Writer) error {
+	if opts.Format != "" && opts.Format != options.FormatTable && opts.Format != options.FormatJSON {
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	ctx := context.Background()
+	session := newSession(ctx, openDiskCache(lg, opts))
+	session.Preload(lg, optionsSources(opts))
+
+	g, err := prepareGeneration(lg, session, opts)
+	if err != nil {
+		return err
+	}
+
+	g.explain = true
+	funcs := copyFunctions(g.funcs)
+
+	var explanations []generator.ConvertorExplanation
+	generated := make(map[string]struct{})
+	for _, opt := range g.options {
+		var res optionResult
+		funcs, res = g.mapOption(ctx, lg, session, opt, funcs, true)
+		if res.err != nil {
+			return res.err
+		}
+
+		explanations = append(explanations, res.explanations...)
+		for _, convertor := range res.convertors {
+			generated[convertor.pkg.Path+"."+convertor.gcf.Function.Name] = struct{}{}
+		}
+	}
+
+	setFunctionLocations(lg, session, explanations, generated)
+
+	if opts.Format == options.FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(explanations)
+	}
+
+	return writeExplanationTable(out, explanations)
+}
+
+func (m *modelMapper) explainConvertor(from, to models.Struct, pkg models.Package, funcs models.Functions) error {
+	if !m.explain {
+		return nil
+	}
+
+	explanation, err := generator.ExplainConvertor(from, to, pkg, funcs)
+	if err != nil {
+		return fmt.Errorf("explain convertor error: %w", err)
+	}
+
+	m.explanations = append(m.explanations, explanation)
+	return nil
+}
+
+// setFunctionLocations sets positions of user and internal conversion functions
+func setFunctionLocations(
+	lg logger.Logger,
+	session *parser.Session,
+	explanations []generator.ConvertorExplanation,
+	generated map[string]struct{},
+) {
+	wd, _ := os.Getwd()
+	for _, explanation := range explanations {
+		for _, field := range explanation.Fields {
+			if field.Function == nil {
+				continue
+			}
+
+			// generated convertors can be not written yet
+			if _, ok := generated[field.Function.Package+"."+field.Function.Name]; ok {
+				field.Function.Generated = true
+				continue
+			}
+
+			position, err := session.FunctionPosition(lg, field.Function.Package, field.Function.Name)
+			if err != nil {
+				continue
+			}
+
+			field.Function.Location = relativePosition(wd, position).String()
+		}
+	}
+}
+
+// relativePosition returns position with file path relative to the working directory if it is inside it
+func relativePosition(wd string, position token.Position) token.Position {
+	if rel, err := filepath.Rel(wd, position.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+		position.Filename = rel
+	}
+
+	return position
+}
+
+func writeExplanationTable(out io.Writer, explanations []generator.ConvertorExplanation) error {
+	for i, explanation := range explanations {
+		if i != 0 {
+			_, _ = fmt.Fprintln(out)
+		}
+
+		withError := ""
+		if explanation.WithError {
+			withError = " (returns error)"
+		}
+
+		_, _ = fmt.Fprintf(out, "%s: %s -> %s%s\n", explanation.Name, explanation.From, explanation.To, withError)
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "TO FIELD\tFROM FIELD\tTAGS\tRULE\tFUNCTION\tLOCATION\tPOINTER\tERROR")
+		for _, field := range explanation.Fields {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				field.ToField+" "+field.ToType,
+				explainFrom(field),
+				explainValue(field.FromTag, field.FromTag+" -> "+field.ToTag),
+				explainRule(field),
+				explainFunction(field.Function),
+				explainLocation(field.Function),
+				explainValue(field.Pointer, field.Pointer),
+				explainError(field),
+			)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func explainValue(check, value string) string {
+	if check == "" {
+		return "-"
+	}
+
+	return value
+}
+
+// explainFrom describes from field, computed field is converted from whole from model
+func explainFrom(field generator.FieldExplanation) string {
+	if field.Computed {
+		return field.FromType
+	}
+
+	return explainValue(field.FromField, field.FromField+" "+field.FromType)
+}
+
+func explainRule(field generator.FieldExplanation) string {
+	if field.Computed {
+		return "computed " + field.Rule.String()
+	}
+
+	if field.Constant != "" {
+		return "constant " + field.Constant
+	}
+
+	if field.FromField == "" {
+		return "not matched"
+	}
+
+	if field.ItemRule != generator.UndefinedRule {
+		return fmt.Sprintf("%s(%s)", field.Rule, field.ItemRule)
+	}
+
+	return field.Rule.String()
+}
+
+func explainFunction(function *generator.FunctionExplanation) string {
+	if function == nil {
+		return "-"
+	}
+
+	return function.Package + "." + function.Name
+}
+
+func explainLocation(function *generator.FunctionExplanation) string {
+	if function != nil && function.Generated {
+		return "generated"
+	}
+
+	if function == nil || function.Location == "" {
+		return "-"
+	}
+
+	return function.Location
+}
+
+func explainError(field generator.FieldExplanation) string {
+	if field.WithError {
+		return "yes"
+	}
+
+	return "no"
+}
@@ -12,7 +12,12 @@ const dash = "-"
 
 var ErrNotFoundInPackage = fmt.Errorf("not found in package")
 
-func TransformAndFilterFields(lg logger.Logger, tagName string, structure models.Struct, head *models.Field,
+func TransformAndFilterFields(
+	lg logger.Logger,
+	session *parser.Session,
+	tagName string,
+	structure models.Struct,
+	head *models.Field,
 ) (models.Struct, error) {
 	structure.Fields = filterFields(tagName, structure.Fields)
 	newStruct := models.Struct{
@@ -32,7 +37,7 @@ func TransformAndFilterFields(lg logger.Logger, tagName string, structure models
 			return nil
 		}
 
-		newField, err := transformAndFilterField(lg, *field)
+		newField, err := transformAndFilterField(lg, session, *field)
 		if err != nil {
 			lg.Errorf("transform field error: %s", err)
 			return err
@@ -71,8 +76,8 @@ func filterFields(tagName string, fields models.Fields) models.Fields {
 	})
 }
 
-func transformAndFilterField(lg logger.Logger, field models.Field) (models.Field, error) {
-	structs, err := parser.ParseModelsByPackage(lg, field.Type.Package.Path)
+func transformAndFilterField(lg logger.Logger, session *parser.Session, field models.Field) (models.Field, error) {
+	structs, err := session.ParseModelsByPackage(lg, field.Type.Package.Path)
 	if err != nil {
 		return models.Field{}, fmt.Errorf("parse dash field error: %w", err)
 	}
@@ -87,7 +92,7 @@ func transformAndFilterField(lg logger.Logger, field models.Field) (models.Field
 		)
 	}
 
-	newStruct, err := TransformAndFilterFields(lg, field.Tags[0].Name, fieldStruct, &field)
+	newStruct, err := TransformAndFilterFields(lg, session, field.Tags[0].Name, fieldStruct, &field)
 	if err != nil {
 		return models.Field{}, fmt.Errorf("transform dash field error: %w", err)
 	}
@@ -0,0 +1,159 @@
+package mapper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/underbek/datamapper/generator"
+	"github.com/underbek/datamapper/loader"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+)
+
+// InternalFunctionsSource is a source of conversion functions of datamapper
+const InternalFunctionsSource = "internal"
+
+// FunctionInfo is a resolved conversion function of from and to types.
+// Generic functions are listed by every expanded pair of types
+type FunctionInfo struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Function  string `json:"function"`
+	Package   string `json:"package"`
+	Generic   bool   `json:"generic"`
+	WithError bool   `json:"with_error"`
+	// Source is a conversion functions source from options or internal
+	Source string `json:"source"`
+	// Overrides is a function of the same types from previous source which is not used
+	Overrides string `json:"overrides,omitempty"`
+}
+
+// ListFunctions prints internal and user conversion functions which are used by mapping
+func ListFunctions(lg logger.Logger, opts options.Options, out io.Writer) error {
+	if opts.Format != "" && opts.Format != options.FormatTable && opts.Format != options.FormatJSON {
+		return fmt.Errorf("%w: %s", ErrUnknownFormat, opts.Format)
+	}
+
+	infos, err := ResolveFunctions(context.Background(), lg, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.FunctionsType != "" {
+		filtered := infos[:0]
+		for _, info := range infos {
+			if matchTypeName(info.From, opts.FunctionsType) || matchTypeName(info.To, opts.FunctionsType) {
+				filtered = append(filtered, info)
+			}
+		}
+
+		infos = filtered
+	}
+
+	if opts.Format == options.FormatJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(infos)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "FROM\tTO\tFUNCTION\tPACKAGE\tERROR\tSOURCE\tOVERRIDES")
+	for _, info := range infos {
+		withError := "no"
+		if info.WithError {
+			withError = "yes"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			info.From, info.To, info.Function, info.Package, withError, info.Source, explainValue(info.Overrides, info.Overrides),
+		)
+	}
+
+	return w.Flush()
+}
+
+// ResolveFunctions returns conversion functions by the same rules as mapping sorted by from and to types
+func ResolveFunctions(ctx context.Context, lg logger.Logger, opts options.Options) ([]FunctionInfo, error) {
+	funcs, err := loader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("parse internal conversion functions error: %w", err)
+	}
+
+	session := newSession(ctx, openDiskCache(lg, opts))
+	userFuncs, cfAliases, err := parseUserFunctions(lg, session, opts.ConversionFunctions)
+	if err != nil {
+		return nil, err
+	}
+
+	computed, err := parseComputedFunctions(lg, session, opts.ComputedFunctions, cfAliases)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make(map[models.ConversionFunctionKey]FunctionInfo, len(funcs))
+	for key, cf := range funcs {
+		infos[key] = newFunctionInfo(cf, InternalFunctionsSource)
+	}
+
+	for i, res := range userFuncs {
+		for key, cf := range excludeComputed(res, computed) {
+			info := newFunctionInfo(cf, opts.ConversionFunctions[i].Source)
+			if previous, ok := infos[key]; ok {
+				info.Overrides = previous.Function + " from " + previous.Source
+			}
+
+			infos[key] = info
+		}
+	}
+
+	res := make([]FunctionInfo, 0, len(infos))
+	for _, info := range infos {
+		res = append(res, info)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].From != res[j].From {
+			return res[i].From < res[j].From
+		}
+
+		if res[i].To != res[j].To {
+			return res[i].To < res[j].To
+		}
+
+		return res[i].Function < res[j].Function
+	})
+
+	return res, nil
+}
+
+func newFunctionInfo(cf models.ConversionFunction, source string) FunctionInfo {
+	return FunctionInfo{
+		From:      generator.TypeName(cf.FromType),
+		To:        generator.TypeName(cf.ToType),
+		Function:  generator.FunctionInstance(cf),
+		Package:   cf.Package.Path,
+		Generic:   cf.TypeParam != models.NoTypeParam,
+		WithError: cf.WithError,
+		Source:    source,
+	}
+}
+
+// matchTypeName matches type by full name or name without package and pointer
+func matchTypeName(typeName, filter string) bool {
+	if typeName == filter {
+		return true
+	}
+
+	name := strings.TrimLeft(typeName, "*")
+	if name == strings.TrimLeft(filter, "*") {
+		return true
+	}
+
+	return name[strings.LastIndex(name, ".")+1:] == filter
+}
@@ -0,0 +1,200 @@
+package mapper
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+)
+
+func parallelism(parallel int) int {
+	if parallel > 0 {
+		return parallel
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// runGroups runs groups of options by workers. Options of a group are mapped serially
+func runGroups(ctx context.Context, workers int, groups [][]int, fn func(group []int)) {
+	jobs := make(chan []int)
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < workers && i < len(groups); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range jobs {
+				fn(group)
+			}
+		}()
+	}
+
+	for _, group := range groups {
+		select {
+		case jobs <- group:
+		case <-ctx.Done():
+			// remaining options fail by context error
+			fn(group)
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+// groupOptions splits options into groups which can be mapped independently.
+// Options are dependent if they can generate or use convertors of the same models:
+// option models and models of their fields from packages of any option.
+// Options keep config order in groups and groups are ordered by their first option.
+func groupOptions(lg logger.Logger, session *parser.Session, opts []options.Option) [][]int {
+	modelPackages := make(map[string]struct{})
+	for _, opt := range opts {
+		for pkg := range optionPackages(lg, session, opt) {
+			modelPackages[pkg] = struct{}{}
+		}
+	}
+
+	parents := make([]int, len(opts))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+
+		return parents[i]
+	}
+
+	union := func(i, j int) {
+		i, j = find(i), find(j)
+		if i < j {
+			parents[j] = i
+		} else {
+			parents[i] = j
+		}
+	}
+
+	owners := make(map[string]int)
+	for i, opt := range opts {
+		types := make(map[string]struct{})
+		for _, model := range opt.Models() {
+			structs, err := session.ParseModelsByPackage(lg, model.Source)
+			if err != nil {
+				continue
+			}
+
+			name, _ := parseModelName(model.Name)
+			if structure, ok := structs[name]; ok {
+				modelTypes(lg, session, structure, modelPackages, types)
+			}
+		}
+
+		for key := range types {
+			if owner, ok := owners[key]; ok {
+				union(owner, i)
+				continue
+			}
+
+			owners[key] = i
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i := range opts {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+
+		groups[root] = append(groups[root], i)
+	}
+
+	res := make([][]int, 0, len(roots))
+	for _, root := range roots {
+		res = append(res, groups[root])
+	}
+
+	return res
+}
+
+func optionPackages(lg logger.Logger, session *parser.Session, opt options.Option) map[string]struct{} {
+	res := make(map[string]struct{})
+	var sources []string
+	for _, model := range opt.Models() {
+		sources = append(sources, model.Source)
+	}
+
+	for _, pair := range opt.RecursivePackages {
+		sources = append(sources, pair.From.Source, pair.To.Source)
+	}
+
+	for _, source := range sources {
+		pkg, err := session.ParsePackage(lg, source)
+		if err != nil {
+			continue
+		}
+
+		res[pkg.Path] = struct{}{}
+	}
+
+	return res
+}
+
+// modelTypes adds model and struct types of its fields from model packages
+func modelTypes(
+	lg logger.Logger,
+	session *parser.Session,
+	model models.Struct,
+	modelPackages map[string]struct{},
+	res map[string]struct{},
+) {
+	key := model.Type.Package.Path + "." + model.Type.Name
+	if _, ok := res[key]; ok {
+		return
+	}
+
+	res[key] = struct{}{}
+
+	model.Fields.Range(func(field models.Field) {
+		fieldType := baseType(field.Type)
+		if fieldType.Kind != models.StructType {
+			return
+		}
+
+		if _, ok := modelPackages[fieldType.Package.Path]; !ok {
+			return
+		}
+
+		structs, err := session.ParseModelsByPackage(lg, fieldType.Package.Path)
+		if err != nil {
+			return
+		}
+
+		if fieldStruct, ok := structs[fieldType.Name]; ok {
+			modelTypes(lg, session, fieldStruct, modelPackages, res)
+		}
+	})
+}
+
+// baseType returns type of items of collections
+func baseType(t models.Type) models.Type {
+	switch additional := t.Additional.(type) {
+	case models.SliceAdditional:
+		return baseType(additional.InType)
+	case models.ArrayAdditional:
+		return baseType(additional.InType)
+	case models.MapAdditional:
+		return baseType(additional.ValueType)
+	default:
+		return t
+	}
+}
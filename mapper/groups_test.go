@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+)
+
+func Test_GroupOptions(t *testing.T) {
+	newOption := func(source, fromName, toName string) options.Option {
+		return options.Option{
+			From: options.Model{Source: "../_test_data/mapper/" + source + "/domain", Name: fromName},
+			To:   options.Model{Source: "../_test_data/mapper/" + source + "/dto", Name: toName},
+		}
+	}
+
+	opts := []options.Option{
+		newOption("selectors", "User", "UserDTO"),
+		newOption("cyclic", "User", "User"),
+		newOption("selectors", "Account", "AccountDTO"),
+		newOption("cyclic", "Team", "Team"),
+		newOption("cyclic", "*Category", "*Category"),
+		newOption("selectors", "User", "UserDTO"),
+		newOption("unknown", "User", "User"),
+	}
+
+	groups := groupOptions(logger.New(), parser.NewSession(context.Background()), opts)
+	assert.Equal(t, [][]int{{0, 5}, {1, 3}, {2}, {4}, {6}}, groups)
+}
@@ -1,11 +1,16 @@
 package mapper
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"go/token"
+	"io"
 	"os"
 	"path"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/underbek/datamapper/generator"
 	"github.com/underbek/datamapper/loader"
@@ -15,91 +20,472 @@ import (
 	"github.com/underbek/datamapper/parser"
 	"github.com/underbek/datamapper/utils"
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 var (
 	ErrNotFoundStruct = errors.New("not found struct error")
 	ErrNotFoundTag    = errors.New("not found tag error")
+	ErrUnknownLayout  = errors.New("unknown layout error")
+	ErrStdoutOption   = errors.New("stdout option error")
 )
 
+// errFieldsReported is returned by mapModel when errors of fields are collected by modelMapper
+var errFieldsReported = errors.New("fields errors are reported")
+
 func MapModels(lg logger.Logger, opts options.Options) error {
+	return mapModels(lg, opts, os.Stdout)
+}
+
+func mapModels(lg logger.Logger, opts options.Options, out io.Writer) error {
+	if opts.Check {
+		return CheckModels(lg, opts, out)
+	}
+
+	if opts.DryRun {
+		return DryRunModels(lg, opts, out)
+	}
+
+	if opts.ListFunctions {
+		return ListFunctions(lg, opts, out)
+	}
+
+	if opts.Explain {
+		return ExplainModels(lg, opts, out)
+	}
+
+	if opts.Watch {
+		return WatchModels(lg, opts, out)
+	}
+
+	if opts.ScaffoldMissing != "" {
+		var err error
+		if opts, err = scaffoldMissing(lg, opts); err != nil {
+			return err
+		}
+	}
+
+	disk := openDiskCache(lg, opts)
+	key := runKey(opts)
+	if disk != nil && key != "" && disk.UpToDate(key) {
+		lg.Info("convertors are up to date")
+		return nil
+	}
+
+	session := newSession(context.Background(), disk)
+	sources, err := generateSources(lg, session, opts, false)
+	if err != nil {
+		return err
+	}
+
+	err = sources.write(lg, out)
+	if err != nil {
+		return err
+	}
+
+	if disk != nil && key != "" {
+		if err = disk.SaveRun(key, session.Dirs(), sources.files()); err != nil {
+			lg.Warn(fmt.Errorf("save cache of run error: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// DryRunModels generates convertors in memory and prints destination files with diffs without writing them
+func DryRunModels(lg logger.Logger, opts options.Options, out io.Writer) error {
+	session := newSession(context.Background(), openDiskCache(lg, opts))
+	sources, err := generateSources(lg, session, opts, true)
+	if err != nil {
+		return err
+	}
+
+	return sources.dryRun(out)
+}
+
+// Generate generates sources of all options in memory without writing destination files
+func Generate(ctx context.Context, lg logger.Logger, opts options.Options) ([]Source, error) {
+	sources, err := generateSources(lg, newSession(ctx, openDiskCache(lg, opts)), opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return sources.list()
+}
+
+// CheckModels generates convertors in memory and prints diffs with destination files without writing them
+func CheckModels(lg logger.Logger, opts options.Options, out io.Writer) error {
+	session := newSession(context.Background(), openDiskCache(lg, opts))
+	sources, err := generateSources(lg, session, opts, true)
+	if err != nil {
+		return err
+	}
+
+	return sources.check(lg, out, opts.SharedDestinations)
+}
+
+func generateSources(lg logger.Logger, session *parser.Session, opts options.Options, readOnly bool,
+) (*convertorSources, error) {
+	start := time.Now()
+	session.Preload(lg, optionsSources(opts))
+	loadDuration := time.Since(start)
+
+	g, err := prepareGeneration(lg, session, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	generationStart := time.Now()
+	groups := groupOptions(lg, session, g.options)
+	results := make([]optionResult, len(g.options))
+
+	runGroups(session.Context(), parallelism(opts.Parallel), groups, func(group []int) {
+		g.mapGroup(lg, session, group, readOnly, results)
+	})
+
+	sources, err := mergeResults(g.layout, results)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Verbose {
+		logTimings(lg, session.Stats(), loadDuration, time.Since(generationStart))
+	}
+
+	return sources, nil
+}
+
+// generation is a state of options mapping shared by all groups of options
+type generation struct {
+	options   []options.Option
+	funcs     models.Functions
+	cfAliases map[string]string
+	layout    string
+	// lossy are conversion functions which fields are not checked by round-trip tests
+	lossy []string
+	// computed are functions of whole from models of computed and conversion functions sources
+	computed []models.ConversionFunction
+	// explain collects explanations of generated convertors
+	explain bool
+}
+
+// prepareGeneration parses conversion functions and expands selectors into options
+func prepareGeneration(lg logger.Logger, session *parser.Session, opts options.Options) (generation, error) {
 	funcs, err := loader.Read()
 	if err != nil {
-		return fmt.Errorf("parse internal conversion functions error: %w", err)
+		return generation{}, fmt.Errorf("parse internal conversion functions error: %w", err)
+	}
+
+	userFuncs, cfAliases, err := parseUserFunctions(lg, session, opts.ConversionFunctions)
+	if err != nil {
+		return generation{}, err
+	}
+
+	cfComputed, err := parseComputedFunctions(lg, session, opts.ConversionFunctions, cfAliases)
+	if err != nil {
+		return generation{}, err
 	}
 
+	computed, err := parseComputedFunctions(lg, session, opts.ComputedFunctions, cfAliases)
+	if err != nil {
+		return generation{}, err
+	}
+
+	// functions of later sources override previous ones.
+	// Functions of computed sources are used only by computed bindings even if their package
+	// is a conversion functions source
+	for _, res := range userFuncs {
+		for key, function := range excludeComputed(res, computed) {
+			funcs[key] = function
+		}
+	}
+
+	// functions of computed sources take precedence over computed functions of conversion functions sources
+	computed = append(computed, cfComputed...)
+
+	layout, err := parseLayout(opts.Layout)
+	if err != nil {
+		return generation{}, err
+	}
+
+	selected, err := expandSelectors(lg, session, opts.Selectors)
+	if err != nil {
+		return generation{}, fmt.Errorf("expand selectors error: %w", err)
+	}
+
+	all := append(slices.Clone(opts.Options), selected...)
+	if err = checkStdoutOptions(all); err != nil {
+		return generation{}, err
+	}
+
+	return generation{
+		options:   all,
+		funcs:     funcs,
+		cfAliases: cfAliases,
+		computed:  computed,
+		layout:    layout,
+		lossy:     opts.LossyFunctions,
+	}, nil
+}
+
+// parseUserFunctions returns conversion functions of sources in order of sources and aliases of their packages
+func parseUserFunctions(lg logger.Logger, session *parser.Session, cfs []options.ConversionFunction,
+) ([]models.Functions, map[string]string, error) {
+	res := make([]models.Functions, 0, len(cfs))
 	cfAliases := map[string]string{}
+	for _, cf := range cfs {
+		funcs, err := session.ParseConversionFunctionsByPackage(lg, cf.Source)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse user conversion functions error: %w", err)
+		}
 
-	if len(opts.ConversionFunctions) != 0 {
-		for _, cf := range opts.ConversionFunctions {
-			res, err := parser.ParseConversionFunctionsByPackage(lg, cf.Source)
-			if err != nil {
-				return fmt.Errorf("parse user conversion functions error: %w", err)
-			}
+		for _, function := range funcs {
+			cfAliases[function.Package.Path] = cf.Alias
+		}
+
+		res = append(res, funcs)
+	}
 
-			for key, function := range res {
-				cfAliases[function.Package.Path] = cf.Alias
-				funcs[key] = function
+	return res, cfAliases, nil
+}
+
+// mapGroup maps options of the group serially into results, options after a failed option are not mapped
+func (g generation) mapGroup(
+	lg logger.Logger,
+	session *parser.Session,
+	group []int,
+	readOnly bool,
+	results []optionResult,
+) {
+	groupFuncs := copyFunctions(g.funcs)
+	for _, index := range group {
+		var res optionResult
+		groupFuncs, res = g.mapOption(session.Context(), lg, session, g.options[index], groupFuncs, readOnly)
+		results[index] = res
+		if res.err != nil {
+			return
+		}
+	}
+}
+
+// mergeResults merges results in order of options so generated sources do not depend on scheduling
+// Errors of fields of all options are returned together
+func mergeResults(layout string, results []optionResult) (*convertorSources, error) {
+	sources := newConvertorSources(layout)
+	var fieldErrors []*generator.FieldError
+	for _, res := range results {
+		var fieldsErr *generator.FieldsError
+		if errors.As(res.err, &fieldsErr) {
+			fieldErrors = append(fieldErrors, fieldsErr.Errors...)
+			continue
+		}
+
+		if res.err != nil {
+			return nil, res.err
+		}
+
+		for _, convertor := range res.convertors {
+			err := sources.add(convertor.destination, convertor.pkg, convertor.gcf, convertor.test)
+			if err != nil {
+				return nil, err
 			}
 		}
 	}
 
-	for _, opt := range opts.Options {
-		fromStructs, err := parser.ParseModelsByPackage(lg, opt.From.Source)
-		if err != nil {
-			return fmt.Errorf("parse models error: %w", err)
+	if len(fieldErrors) != 0 {
+		return nil, &generator.FieldsError{Errors: fieldErrors}
+	}
+
+	return sources, nil
+}
+
+type optionResult struct {
+	convertors   []pendingConvertor
+	explanations []generator.ConvertorExplanation
+	err          error
+}
+
+func (g generation) mapOption(
+	ctx context.Context,
+	lg logger.Logger,
+	session *parser.Session,
+	opt options.Option,
+	funcs models.Functions,
+	readOnly bool,
+) (models.Functions, optionResult) {
+	if err := ctx.Err(); err != nil {
+		return funcs, optionResult{err: err}
+	}
+
+	if len(opt.FromModels) != 0 {
+		return g.mapMergeOption(lg, session, opt, funcs, readOnly)
+	}
+
+	if len(opt.ToModels) != 0 {
+		return g.mapSplitOption(lg, session, opt, funcs, readOnly)
+	}
+
+	if opt.Strict {
+		return funcs, optionResult{err: fmt.Errorf("%w: strict is supported only with to-models", ErrSplitOption)}
+	}
+
+	fromStructs, err := session.ParseModelsByPackage(lg, opt.From.Source)
+	if err != nil {
+		return funcs, optionResult{err: fmt.Errorf("parse models error: %w", err)}
+	}
+
+	fromName, isFromPointer := parseModelName(opt.From.Name)
+	from, ok := fromStructs[fromName]
+	if !ok {
+		return funcs, optionResult{
+			err: fmt.Errorf(" %w: source model %s from %s", ErrNotFoundStruct, opt.From.Name, opt.From.Source),
 		}
+	}
+	from.Type.Pointer = isFromPointer
 
-		fromName, isFromPointer := parseModelName(opt.From.Name)
-		from, ok := fromStructs[fromName]
-		if !ok {
-			return fmt.Errorf(" %w: source model %s from %s", ErrNotFoundStruct, opt.From.Name, opt.From.Source)
+	toStructs, err := session.ParseModelsByPackage(lg, opt.To.Source)
+	if err != nil {
+		return funcs, optionResult{err: fmt.Errorf("parse models error: %w", err)}
+	}
+
+	toName, isToPointer := parseModelName(opt.To.Name)
+	to, ok := toStructs[toName]
+	if !ok {
+		return funcs, optionResult{
+			err: fmt.Errorf("%w: to model %s from %s", ErrNotFoundStruct, opt.To.Name, opt.To.Source),
 		}
-		from.Type.Pointer = isFromPointer
+	}
+	to.Type.Pointer = isToPointer
 
-		toStructs, err := parser.ParseModelsByPackage(lg, opt.To.Source)
-		if err != nil {
-			return fmt.Errorf("parse models error: %w", err)
+	aliases := map[string]string{
+		from.Type.Package.Path: opt.From.Alias,
+		to.Type.Package.Path:   opt.To.Alias,
+	}
+
+	recursivePackages, err := parseRecursivePackages(lg, session, opt.RecursivePackages, aliases)
+	if err != nil {
+		return funcs, optionResult{err: fmt.Errorf("parse recursive packages error: %w", err)}
+	}
+
+	maps.Copy(aliases, g.cfAliases)
+
+	m := g.newModelMapper(lg, session, opt, aliases, recursivePackages, readOnly)
+	m.rootTo = to.Type
+	res, err := m.mapRootModel(from, to, opt.Destination, funcs, fromStructs, toStructs)
+	if err != nil {
+		return funcs, optionResult{err: err}
+	}
+
+	return res, optionResult{convertors: m.convertors, explanations: m.explanations}
+}
+
+func (g generation) newModelMapper(
+	lg logger.Logger,
+	session *parser.Session,
+	opt options.Option,
+	aliases map[string]string,
+	recursivePackages map[packagePair]struct{},
+	readOnly bool,
+) *modelMapper {
+	return &modelMapper{
+		lg:                lg,
+		session:           session,
+		fromTag:           opt.From.Tag,
+		toTag:             opt.To.Tag,
+		inverse:           opt.Inverse,
+		recursive:         opt.Recursive,
+		withPointers:      opt.WithPointers,
+		withSlice:         opt.WithSlice,
+		roundTrip:         opt.RoundTripTests,
+		diff:              opt.Diff,
+		apply:             opt.Apply,
+		inPlace:           opt.InPlace,
+		lossy:             g.lossy,
+		computed:          g.computed,
+		computedFields:    opt.Computed,
+		constants:         opt.Constants,
+		defaults:          opt.Defaults,
+		aliases:           aliases,
+		recursivePackages: recursivePackages,
+		layout:            g.layout,
+		readOnly:          readOnly,
+		explain:           g.explain,
+	}
+}
+
+// optionsSources returns all model, conversion functions and destination sources of options
+func optionsSources(opts options.Options) []string {
+	var res []string
+	for _, cf := range append(slices.Clone(opts.ConversionFunctions), opts.ComputedFunctions...) {
+		res = append(res, cf.Source)
+	}
+
+	addOption := func(models []options.Model, destination string, pairs []options.PackagePair) {
+		for _, model := range models {
+			res = append(res, model.Source)
 		}
 
-		toName, isToPointer := parseModelName(opt.To.Name)
-		to, ok := toStructs[toName]
-		if !ok {
-			return fmt.Errorf("%w: to model %s from %s", ErrNotFoundStruct, opt.To.Name, opt.To.Source)
+		for _, pair := range pairs {
+			res = append(res, pair.From.Source, pair.To.Source)
 		}
-		to.Type.Pointer = isToPointer
 
-		aliases := map[string]string{
-			from.Type.Package.Path: opt.From.Alias,
-			to.Type.Package.Path:   opt.To.Alias,
+		if destination == options.StdoutDestination {
+			destination = "."
 		}
 
-		maps.Copy(aliases, cfAliases)
+		res = append(res, destination)
+	}
 
-		funcs, err = mapModel(
-			lg,
-			from,
-			to,
-			opt.From.Tag,
-			opt.To.Tag,
-			opt.Destination,
-			opt.Inverse,
-			opt.Recursive,
-			opt.WithPointers,
-			opt.WithSlice,
-			aliases,
-			funcs,
-			fromStructs,
-			toStructs,
-		)
-		if err != nil {
-			return err
+	for _, opt := range opts.Options {
+		addOption(opt.Models(), opt.Destination, opt.RecursivePackages)
+	}
+
+	for _, selector := range opts.Selectors {
+		addOption([]options.Model{selector.From, selector.To}, selector.Destination, selector.RecursivePackages)
+	}
+
+	return res
+}
+
+func logTimings(lg logger.Logger, stats parser.Stats, loadDuration, generationDuration time.Duration) {
+	lg.Infof(
+		"timing: batch load %s (%d calls), preparation %s, generation %s",
+		stats.BatchLoadDuration, stats.BatchLoads, loadDuration, generationDuration,
+	)
+	lg.Infof(
+		"timing: single loads %s (%d calls), parse models %s, parse conversion functions %s (cumulative)",
+		stats.SingleLoadDuration, stats.SingleLoads, stats.ModelsDuration, stats.FunctionsDuration,
+	)
+	if stats.DiskHits != 0 {
+		lg.Infof("cache: %d parsed sources read from disk", stats.DiskHits)
+	}
+}
+
+func checkStdoutOptions(opts []options.Option) error {
+	if len(opts) < 2 {
+		return nil
+	}
+
+	for _, opt := range opts {
+		if opt.Destination == options.StdoutDestination {
+			return fmt.Errorf("%w: only one option can be written to stdout", ErrStdoutOption)
 		}
 	}
 
 	return nil
 }
 
+func parseLayout(layout string) (string, error) {
+	switch layout {
+	case "":
+		return options.LayoutModel, nil
+	case options.LayoutOption, options.LayoutModel, options.LayoutPackage:
+		return layout, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownLayout, layout)
+	}
+}
+
 func setPackageAlias(p *models.Package, aliases map[string]string) {
 	p.Alias = aliases[p.Path]
 }
@@ -112,6 +498,15 @@ func setTypePackageAlias(t *models.Type, aliases map[string]string) {
 		additional := t.Additional.(models.SliceAdditional)
 		setTypePackageAlias(&additional.InType, aliases)
 		t.Additional = additional
+	case models.ArrayType:
+		additional := t.Additional.(models.ArrayAdditional)
+		setTypePackageAlias(&additional.InType, aliases)
+		t.Additional = additional
+	case models.MapType:
+		additional := t.Additional.(models.MapAdditional)
+		setTypePackageAlias(&additional.KeyType, aliases)
+		setTypePackageAlias(&additional.ValueType, aliases)
+		t.Additional = additional
 	}
 }
 
@@ -161,22 +556,228 @@ func parseModelName(modelName string) (string, bool) {
 	return modelName, false
 }
 
-func mapModel(
+type packagePair struct {
+	from, to string
+}
+
+type pendingConvertor struct {
+	destination string
+	pkg         models.Package
+	gcf         models.GeneratedConversionFunction
+	// test is a round-trip test which is written into test file of destination
+	test bool
+}
+
+type modelMapper struct {
+	lg           logger.Logger
+	session      *parser.Session
+	fromTag      string
+	toTag        string
+	inverse      bool
+	recursive    bool
+	withPointers bool
+	withSlice    bool
+	roundTrip    bool
+	lossy        []string
+	diff         bool
+	apply        bool
+	inPlace      bool
+	aliases      map[string]string
+	computed     []models.ConversionFunction
+	// fields of rootTo model are bound to computed functions and values by paths of Go names,
+	// fields of all models are bound by options of tags
+	rootTo         models.Type
+	computedFields map[string]string
+	constants      map[string]string
+	defaults       map[string]string
+	// pairs of other packages which models can be mapped recursively
+	recursivePackages map[packagePair]struct{}
+	layout            string
+	// do not create destination directories
+	readOnly bool
+	explain  bool
+
+	// convertors of models which are being generated, value is expected error result of convertor
+	inProgress map[models.ConversionFunctionKey]bool
+	// convertors of cyclic models which must return error
+	errorPairs   map[models.ConversionFunctionKey]struct{}
+	retry        bool
+	convertors   []pendingConvertor
+	explanations []generator.ConvertorExplanation
+	// errors of unconvertible fields of all models, failed models are not mapped again
+	fieldErrors []*generator.FieldError
+	failedPairs map[models.ConversionFunctionKey]struct{}
+}
+
+func parseRecursivePackages(
 	lg logger.Logger,
+	session *parser.Session,
+	pairs []options.PackagePair,
+	aliases map[string]string,
+) (map[packagePair]struct{}, error) {
+	res := make(map[packagePair]struct{}, len(pairs))
+	for _, pair := range pairs {
+		fromPkg, err := session.ParsePackage(lg, pair.From.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		toPkg, err := session.ParsePackage(lg, pair.To.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		fromPkg.Alias = pair.From.Alias
+		toPkg.Alias = pair.To.Alias
+
+		// packages with same names cannot be imported into one destination without aliases
+		if fromPkg.Name == toPkg.Name && fromPkg.Alias == "" && toPkg.Alias == "" {
+			fromPkg.Alias = generatePackageAlias(fromPkg.Path)
+			toPkg.Alias = generatePackageAlias(toPkg.Path)
+		}
+
+		aliases[fromPkg.Path] = fromPkg.Alias
+		aliases[toPkg.Path] = toPkg.Alias
+
+		res[packagePair{from: fromPkg.Path, to: toPkg.Path}] = struct{}{}
+	}
+
+	return res, nil
+}
+
+func generatePackageAlias(pkgPath string) string {
+	names := strings.Split(pkgPath, "/")
+	if len(names) > 1 {
+		names = names[len(names)-2:]
+	}
+
+	alias := strings.ToLower(strings.Join(names, ""))
+	return strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+
+		return -1
+	}, alias)
+}
+
+func (m *modelMapper) isRecursivePackages(from, to, currentFrom, currentTo models.Package) bool {
+	if from == currentFrom && to == currentTo {
+		return true
+	}
+
+	_, ok := m.recursivePackages[packagePair{from: from.Path, to: to.Path}]
+	return ok
+}
+
+func (m *modelMapper) structsByPackage(pkg, current models.Package, structs map[string]models.Struct,
+) (map[string]models.Struct, error) {
+	if pkg == current {
+		return structs, nil
+	}
+
+	return m.session.ParseModelsByPackage(m.lg, pkg.Path)
+}
+
+// mapRootModel maps models of option
+func (m *modelMapper) mapRootModel(
+	from, to models.Struct,
+	destination string,
+	funcs models.Functions,
+	fromStructs, toStructs map[string]models.Struct,
+) (models.Functions, error) {
+	return m.mapRoot(func() (models.Functions, error) {
+		return m.mapModel(from, to, destination, copyFunctions(funcs), fromStructs, toStructs)
+	})
+}
+
+// mapRoot maps models of option by mapFn and repeats it while convertors of cyclic models
+// do not match the expected error results
+func (m *modelMapper) mapRoot(mapFn func() (models.Functions, error)) (models.Functions, error) {
+	m.errorPairs = make(map[models.ConversionFunctionKey]struct{})
+
+	for {
+		m.inProgress = make(map[models.ConversionFunctionKey]bool)
+		m.retry = false
+		m.convertors = nil
+		m.explanations = nil
+		m.fieldErrors = nil
+		m.failedPairs = make(map[models.ConversionFunctionKey]struct{})
+
+		res, err := mapFn()
+		if errors.Is(err, errFieldsReported) {
+			return nil, m.fieldsError()
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !m.retry {
+			return res, nil
+		}
+	}
+}
+
+func copyFunctions(funcs models.Functions) models.Functions {
+	res := make(models.Functions, len(funcs))
+	for key, cf := range funcs {
+		res[key] = cf
+	}
+
+	return res
+}
+
+func modelsPairKey(from, to models.Type) models.ConversionFunctionKey {
+	from.Pointer = false
+	to.Pointer = false
+
+	return models.ConversionFunctionKey{
+		FromType: from,
+		ToType:   to,
+	}
+}
+
+// startPair adds expected convertor of models to functions, so cyclic models can use it before generation
+func (m *modelMapper) startPair(from, to models.Type, pkg models.Package, funcs models.Functions) {
+	key := modelsPairKey(from, to)
+	if _, ok := funcs[key]; ok {
+		return
+	}
+
+	_, withError := m.errorPairs[key]
+	m.inProgress[key] = withError
+	funcs[key] = generator.ConvertorFunction(from, to, pkg, withError)
+}
+
+// finishPair replaces expected convertor by generated one
+func (m *modelMapper) finishPair(gcf models.GeneratedConversionFunction, funcs models.Functions) {
+	key := modelsPairKey(gcf.Function.FromType, gcf.Function.ToType)
+	if withError, ok := m.inProgress[key]; ok {
+		delete(m.inProgress, key)
+		delete(funcs, key)
+
+		if gcf.Function.WithError && !withError {
+			m.errorPairs[key] = struct{}{}
+			m.retry = true
+		}
+	}
+
+	funcs[models.ConversionFunctionKey{
+		FromType: gcf.Function.FromType,
+		ToType:   gcf.Function.ToType,
+	}] = gcf.Function
+}
+
+func (m *modelMapper) mapModel(
 	from, to models.Struct,
-	fromTag, toTag string,
 	destination string,
-	inverse bool,
-	recursive bool,
-	withPointers bool,
-	withSlice bool,
-	aliases map[string]string,
 	funcs models.Functions,
 	fromStructs, toStructs map[string]models.Struct,
 ) (models.Functions, error) {
 
 	var err error
-	from, err = TransformAndFilterFields(lg, fromTag, from, nil)
+	from, err = TransformAndFilterFields(m.lg, m.session, m.fromTag, from, nil)
 	if err != nil {
 		return nil, fmt.Errorf("transform and filter fields error: %w", err)
 	}
@@ -186,11 +787,11 @@ func mapModel(
 			"%w: source model %s does not contain tag %s",
 			ErrNotFoundTag,
 			from.Type.Name,
-			fromTag,
+			m.fromTag,
 		)
 	}
 
-	to, err = TransformAndFilterFields(lg, toTag, to, nil)
+	to, err = TransformAndFilterFields(m.lg, m.session, m.toTag, to, nil)
 	if err != nil {
 		return nil, fmt.Errorf("transform and filter fields error: %w", err)
 	}
@@ -200,136 +801,427 @@ func mapModel(
 			"%w: to model %s does not contain tag %s",
 			ErrNotFoundTag,
 			to.Type.Name,
-			toTag,
+			m.toTag,
 		)
 	}
 
 	// set aliases
-	setPackageAliasToStruct(&from, aliases)
-	setPackageAliasToStruct(&to, aliases)
+	setPackageAliasToStruct(&from, m.aliases)
+	setPackageAliasToStruct(&to, m.aliases)
 
-	err = os.MkdirAll(path.Dir(destination), os.ModePerm)
-	if err != nil {
-		return nil, fmt.Errorf("create destination dir %s error: %w", path.Dir(destination), err)
+	if err = m.bindComputed(&to, from.Type); err != nil {
+		return nil, err
+	}
+
+	if err = m.bindValues(&to, from); err != nil {
+		return nil, err
 	}
 
-	pkg, err := parser.ParseDestinationPackage(lg, destination)
+	if m.inverse {
+		if err = m.bindComputed(&from, to.Type); err != nil {
+			return nil, err
+		}
+
+		if err = m.bindValues(&from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	pkg, err := m.destinationPackage(destination)
 	if err != nil {
-		return nil, fmt.Errorf("parse destination package %s error: %w", destination, err)
+		return nil, err
 	}
 
-	var convertors []string
-	pkgs := make(models.Packages)
+	funcs = setPackageAliasToFunctions(funcs, m.aliases)
+	m.startPair(from.Type, to.Type, pkg, funcs)
+	if m.inverse {
+		m.startPair(to.Type, from.Type, pkg, funcs)
+	}
+
+	var gcfs []models.GeneratedConversionFunction
 	var gcf models.GeneratedConversionFunction
 	for {
-		funcs = setPackageAliasToFunctions(funcs, aliases)
-		gcf, err = generator.GenerateConvertor(from, to, fromTag, toTag, pkg, funcs)
+		funcs = setPackageAliasToFunctions(funcs, m.aliases)
+		gcf, err = generator.GenerateConvertor(from, to, m.fromTag, m.toTag, pkg, funcs)
 		if err == nil {
-			convertors = append(convertors, gcf.Body)
-			funcs[models.ConversionFunctionKey{
-				FromType: gcf.Function.FromType,
-				ToType:   gcf.Function.ToType,
-			}] = gcf.Function
-			maps.Copy(pkgs, gcf.Packages)
+			if err = m.explainConvertor(from, to, pkg, funcs); err != nil {
+				return nil, err
+			}
+
+			gcfs = append(gcfs, gcf)
+			m.finishPair(gcf, funcs)
 			break
 		}
 
-		if !recursive {
+		var fieldsErr *generator.FieldsError
+		if !errors.As(err, &fieldsErr) {
 			return nil, err
 		}
 
-		var findError *generator.FindFieldsPairError
-		if !errors.As(err, &findError) {
+		var resolved bool
+		funcs, resolved, err = m.mapNestedModels(fieldsErr, from, to, destination, funcs, fromStructs, toStructs)
+		if err != nil {
 			return nil, err
 		}
 
-		if findError.From.Package != from.Type.Package || findError.To.Package != to.Type.Package {
-			return nil, err
+		if resolved {
+			continue
 		}
 
-		fromField, fromOk := fromStructs[findError.From.Name]
-		toField, toOk := toStructs[findError.To.Name]
+		m.reportFields(fieldsErr)
 
-		if !fromOk || !toOk {
-			return nil, err
-		}
-
-		if withPointers {
-			fromField.Type.Pointer = findError.From.Pointer
-			toField.Type.Pointer = findError.To.Pointer
+		// inverse convertor is checked too, so errors of both directions are reported by one run
+		if m.inverse {
+			_, err = generator.GenerateConvertor(to, from, m.toTag, m.fromTag, pkg, funcs)
+			if errors.As(err, &fieldsErr) {
+				m.reportFields(fieldsErr)
+			}
 		}
 
-		funcs, err = mapModel(
-			lg,
-			fromField,
-			toField,
-			fromTag,
-			toTag,
-			generateDestination(fromField.Type.Name, destination),
-			inverse,
-			recursive,
-			withPointers,
-			withSlice,
-			aliases,
-			funcs,
-			fromStructs,
-			toStructs,
-		)
-		if err != nil {
-			return nil, err
-		}
+		m.failPair(from.Type, to.Type)
+		return nil, errFieldsReported
 	}
 
-	if withSlice {
+	if m.withSlice {
 		gcf, err := generator.GenerateSliceConvertor(from.Type, to.Type, pkg, gcf.Function)
 		if err != nil {
 			return nil, fmt.Errorf("generate convertor slice error: %w", err)
 		}
-		convertors = append(convertors, gcf.Body)
+		gcfs = append(gcfs, gcf)
 		funcs[models.ConversionFunctionKey{
 			FromType: gcf.Function.FromType,
 			ToType:   gcf.Function.ToType,
 		}] = gcf.Function
-		maps.Copy(pkgs, gcf.Packages)
 	}
 
-	if inverse {
-		gcf, err := generator.GenerateConvertor(to, from, toTag, fromTag, pkg, funcs)
+	if m.inverse {
+		gcf, err := generator.GenerateConvertor(to, from, m.toTag, m.fromTag, pkg, funcs)
+		var fieldsErr *generator.FieldsError
+		if errors.As(err, &fieldsErr) {
+			m.reportFields(fieldsErr)
+			m.failPair(from.Type, to.Type)
+			return nil, errFieldsReported
+		}
+
 		if err != nil {
 			return nil, fmt.Errorf("generate convertor error: %w", err)
 		}
-		convertors = append(convertors, gcf.Body)
-		funcs[models.ConversionFunctionKey{
-			FromType: gcf.Function.FromType,
-			ToType:   gcf.Function.ToType,
-		}] = gcf.Function
-		maps.Copy(pkgs, gcf.Packages)
 
-		if withSlice {
+		if err = m.explainConvertor(to, from, pkg, funcs); err != nil {
+			return nil, err
+		}
+		gcfs = append(gcfs, gcf)
+		m.finishPair(gcf, funcs)
+
+		if m.withSlice {
 			gcf, err := generator.GenerateSliceConvertor(to.Type, from.Type, pkg, gcf.Function)
 			if err != nil {
 				return nil, fmt.Errorf("generate convertor slice error: %w", err)
 			}
-			convertors = append(convertors, gcf.Body)
+			gcfs = append(gcfs, gcf)
 			funcs[models.ConversionFunctionKey{
 				FromType: gcf.Function.FromType,
 				ToType:   gcf.Function.ToType,
 			}] = gcf.Function
-			maps.Copy(pkgs, gcf.Packages)
 		}
 	}
 
-	err = generator.CreateConvertorSource(pkg, pkgs, convertors, destination)
-	if err != nil {
-		return nil, fmt.Errorf("create convertor source error: %w", err)
+	if m.diff {
+		diffs, err := m.generateHelpers("diff", generator.GenerateDiff, from, to, pkg, funcs)
+		if err != nil {
+			return nil, err
+		}
+
+		gcfs = append(gcfs, diffs...)
+	}
+
+	if m.apply {
+		applies, err := m.generateHelpers("apply", generator.GenerateApply, from, to, pkg, funcs)
+		if err != nil {
+			return nil, err
+		}
+
+		gcfs = append(gcfs, applies...)
+	}
+
+	if m.inPlace {
+		for _, generate := range []helperGenerator{
+			generator.GenerateInPlaceConvertor,
+			generator.GenerateInPlaceSliceConvertor,
+		} {
+			convertors, err := m.generateHelpers("in-place convertor", generate, from, to, pkg, funcs)
+			if err != nil {
+				return nil, err
+			}
+
+			gcfs = append(gcfs, convertors...)
+		}
+	}
+
+	for _, gcf := range gcfs {
+		m.convertors = append(m.convertors, pendingConvertor{
+			destination: destination,
+			pkg:         pkg,
+			gcf:         gcf,
+		})
+	}
+
+	if m.inverse {
+		if err = m.addRoundTripTest(from, to, destination, pkg, funcs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = m.addBenchmarks(from, to, destination, pkg, funcs); err != nil {
+		return nil, err
 	}
-	lg.Infof("generated convertor source: \"%s\"", destination)
 
 	return funcs, nil
 }
 
-func generateDestination(typeName, dest string) string {
-	fileName := strings.ToLower(fmt.Sprintf("%s_converter.go", typeName))
+// destinationPackage creates directory of destination if it is needed and returns package of destination
+func (m *modelMapper) destinationPackage(destination string) (models.Package, error) {
+	// source printed to stdout belongs to the current directory package
+	pkgDestination := destination
+	if destination == options.StdoutDestination {
+		pkgDestination = "."
+	}
+
+	if !m.readOnly && destination != options.StdoutDestination {
+		err := os.MkdirAll(path.Dir(destination), os.ModePerm)
+		if err != nil {
+			return models.Package{}, fmt.Errorf("create destination dir %s error: %w", path.Dir(destination), err)
+		}
+	}
+
+	pkg, err := m.session.ParseDestinationPackage(m.lg, pkgDestination)
+	if err != nil {
+		return models.Package{}, fmt.Errorf("parse destination package %s error: %w", destination, err)
+	}
+
+	return pkg, nil
+}
+
+// addBenchmarks generates benchmarks of in-place convertors if they are enabled
+func (m *modelMapper) addBenchmarks(from, to models.Struct, destination string, pkg models.Package,
+	funcs models.Functions) error {
+	// tests of sources printed to stdout cannot be written
+	if !m.inPlace || destination == options.StdoutDestination {
+		return nil
+	}
+
+	benchmarks, err := m.generateHelpers("benchmark", generator.GenerateInPlaceBenchmark, from, to, pkg, funcs)
+	if err != nil {
+		return err
+	}
+
+	for _, benchmark := range benchmarks {
+		m.convertors = append(m.convertors, pendingConvertor{
+			destination: destination,
+			pkg:         pkg,
+			gcf:         benchmark,
+			test:        true,
+		})
+	}
+
+	return nil
+}
+
+// helperGenerator generates function of models pair which is written next to their convertors
+type helperGenerator func(from, to models.Struct, fromTag, toTag string, pkg models.Package,
+	functions models.Functions) (models.GeneratedConversionFunction, error)
+
+// generateHelpers generates helpers of direct and inverse convertors
+func (m *modelMapper) generateHelpers(kind string, generate helperGenerator, from, to models.Struct,
+	pkg models.Package, funcs models.Functions) ([]models.GeneratedConversionFunction, error) {
+	gcf, err := generate(from, to, m.fromTag, m.toTag, pkg, funcs)
+	if err != nil {
+		return nil, fmt.Errorf("generate %s error: %w", kind, err)
+	}
+
+	res := []models.GeneratedConversionFunction{gcf}
+	if !m.inverse {
+		return res, nil
+	}
+
+	gcf, err = generate(to, from, m.toTag, m.fromTag, pkg, funcs)
+	if err != nil {
+		return nil, fmt.Errorf("generate %s error: %w", kind, err)
+	}
+
+	return append(res, gcf), nil
+}
+
+// addRoundTripTest generates round-trip test of inverse convertors if it is enabled
+func (m *modelMapper) addRoundTripTest(from, to models.Struct, destination string, pkg models.Package,
+	funcs models.Functions) error {
+	// tests of sources printed to stdout cannot be written
+	if !m.roundTrip || destination == options.StdoutDestination {
+		return nil
+	}
+
+	test, err := generator.GenerateRoundTripTest(from, to, pkg, funcs, m.lossy)
+	if err != nil {
+		return fmt.Errorf("generate round-trip test error: %w", err)
+	}
+
+	if test.Body == "" {
+		m.lg.Infof("skip round-trip test of %s and %s: no fields of fuzzing types", from.Type.Name, to.Type.Name)
+		return nil
+	}
+
+	m.convertors = append(m.convertors, pendingConvertor{
+		destination: destination,
+		pkg:         pkg,
+		gcf:         test,
+		test:        true,
+	})
+
+	return nil
+}
+
+// mapNestedModels maps models of unconvertible fields recursively.
+// It returns false if none of models are mapped, errors of failed models are reported
+func (m *modelMapper) mapNestedModels(
+	fieldsErr *generator.FieldsError,
+	from, to models.Struct,
+	destination string,
+	funcs models.Functions,
+	fromStructs, toStructs map[string]models.Struct,
+) (models.Functions, bool, error) {
+	if !m.recursive {
+		return funcs, false, nil
+	}
+
+	resolved := false
+	mapped := make(map[models.ConversionFunctionKey]struct{})
+	for _, fieldErr := range fieldsErr.Errors {
+		var findError *generator.FindFieldsPairError
+		if !errors.As(fieldErr, &findError) {
+			continue
+		}
+
+		if !m.isRecursivePackages(findError.From.Package, findError.To.Package, from.Type.Package, to.Type.Package) {
+			continue
+		}
+
+		key := modelsPairKey(findError.From, findError.To)
+
+		// convertor of models in progress is already in functions, so other types cannot be converted
+		if _, ok := m.inProgress[key]; ok {
+			continue
+		}
+
+		if _, ok := m.failedPairs[key]; ok {
+			continue
+		}
+
+		// models of several fields are mapped once, other pointer variants are mapped by the next attempt
+		if _, ok := mapped[key]; ok {
+			continue
+		}
+		mapped[key] = struct{}{}
+
+		nestedFromStructs, err := m.structsByPackage(findError.From.Package, from.Type.Package, fromStructs)
+		if err != nil {
+			return nil, false, fmt.Errorf("parse models error: %w", err)
+		}
+
+		nestedToStructs, err := m.structsByPackage(findError.To.Package, to.Type.Package, toStructs)
+		if err != nil {
+			return nil, false, fmt.Errorf("parse models error: %w", err)
+		}
+
+		fromField, fromOk := nestedFromStructs[findError.From.Name]
+		toField, toOk := nestedToStructs[findError.To.Name]
+
+		if !fromOk || !toOk {
+			continue
+		}
+
+		if m.withPointers {
+			fromField.Type.Pointer = findError.From.Pointer
+			toField.Type.Pointer = findError.To.Pointer
+		}
+
+		nestedFuncs, err := m.mapModel(
+			fromField,
+			toField,
+			generateDestination(m.layout, fromField.Type, from.Type, destination),
+			funcs,
+			nestedFromStructs,
+			nestedToStructs,
+		)
+		if errors.Is(err, errFieldsReported) {
+			continue
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		funcs = nestedFuncs
+		resolved = true
+	}
+
+	return funcs, resolved, nil
+}
+
+// reportFields collects errors of fields except fields of failed models which errors are already reported
+func (m *modelMapper) reportFields(fieldsErr *generator.FieldsError) {
+	for _, fieldErr := range fieldsErr.Errors {
+		var findError *generator.FindFieldsPairError
+		if errors.As(fieldErr, &findError) {
+			if _, ok := m.failedPairs[modelsPairKey(findError.From, findError.To)]; ok {
+				continue
+			}
+		}
+
+		m.fieldErrors = append(m.fieldErrors, fieldErr)
+	}
+}
+
+// failPair marks models as failed in both directions, so fields of these models are not reported again
+func (m *modelMapper) failPair(from, to models.Type) {
+	for _, key := range []models.ConversionFunctionKey{modelsPairKey(from, to), modelsPairKey(to, from)} {
+		delete(m.inProgress, key)
+		m.failedPairs[key] = struct{}{}
+	}
+}
+
+// fieldsError returns reported errors of fields with positions of fields
+func (m *modelMapper) fieldsError() *generator.FieldsError {
+	wd, _ := os.Getwd()
+	for _, fieldErr := range m.fieldErrors {
+		fieldErr.SetPositions(
+			relativePosition(wd, m.fieldPosition(fieldErr.From, fieldErr.FromField)),
+			relativePosition(wd, m.fieldPosition(fieldErr.To, fieldErr.ToField)),
+		)
+	}
+
+	return &generator.FieldsError{Errors: m.fieldErrors}
+}
+
+// fieldPosition returns position of field of model or of embedded struct of the field
+func (m *modelMapper) fieldPosition(model models.Type, field models.Field) token.Position {
+	if field.Head != nil {
+		model = field.Head.Type
+	}
+
+	return m.session.FieldPosition(model, field.Name)
+}
+
+func generateDestination(layout string, model, parent models.Type, dest string) string {
+	if layout != options.LayoutModel || dest == options.StdoutDestination {
+		return dest
+	}
+
+	name := model.Name
+	if model.Package.Path != parent.Package.Path {
+		name = fmt.Sprintf("%s_%s", model.Package.Name, model.Name)
+	}
+
+	fileName := strings.ToLower(fmt.Sprintf("%s_converter.go", name))
 	dir := utils.ClearFileName(dest)
 	return fmt.Sprintf("%s/%s", dir, fileName)
 }
@@ -1,15 +1,22 @@
 package mapper
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/underbek/datamapper/_test_data"
+	"github.com/underbek/datamapper/generator"
 	"github.com/underbek/datamapper/logger"
 	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
 )
 
 const (
@@ -440,6 +447,7 @@ func Test_MapRecursiveModels(t *testing.T) {
 		opts         options.Options
 		isError      bool
 		expectedPath string
+		converters   []string
 	}{
 		{
 			name:    "without recursive",
@@ -514,6 +522,55 @@ func Test_MapRecursiveModels(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:         "recursive with option layout",
+			expectedPath: "recursive_with_option_layout",
+			converters:   []string{"order.go"},
+			opts: options.Options{
+				Layout: options.LayoutOption,
+				Options: []options.Option{
+					{
+						Destination: destination,
+						Recursive:   true,
+						Inverse:     true,
+						From:        from,
+						To:          to,
+					},
+				},
+			},
+		},
+		{
+			name:         "recursive with package layout",
+			expectedPath: "recursive_with_option_layout",
+			converters:   []string{"order.go"},
+			opts: options.Options{
+				Layout: options.LayoutPackage,
+				Options: []options.Option{
+					{
+						Destination: destination,
+						Recursive:   true,
+						Inverse:     true,
+						From:        from,
+						To:          to,
+					},
+				},
+			},
+		},
+		{
+			name:    "unknown layout",
+			isError: true,
+			opts: options.Options{
+				Layout: "unknown",
+				Options: []options.Option{
+					{
+						Destination: destination,
+						Recursive:   true,
+						From:        from,
+						To:          to,
+					},
+				},
+			},
+		},
 	}
 
 	lg := logger.New()
@@ -537,6 +594,11 @@ func Test_MapRecursiveModels(t *testing.T) {
 
 			require.NoError(t, err)
 
+			converters := converters
+			if tt.converters != nil {
+				converters = tt.converters
+			}
+
 			for _, converterName := range converters {
 				actual := readFile(t, converterName)
 				expected := _test_data.MapperExpectedFile(t, tt.expectedPath, converterName)
@@ -622,3 +684,1414 @@ func Test_MapWithDash(t *testing.T) {
 		})
 	}
 }
+
+func Test_MapSelectors(t *testing.T) {
+	from := options.Model{
+		Source: "../_test_data/mapper/selectors/domain",
+		Tag:    modelTag,
+	}
+
+	to := options.Model{
+		Source: "../_test_data/mapper/selectors/dto",
+		Name:   "{Name}DTO",
+		Tag:    toModelTag,
+	}
+
+	tests := []struct {
+		name         string
+		selector     options.Selector
+		isError      bool
+		expectedPath string
+		converters   []string
+	}{
+		{
+			name: "With include",
+			selector: options.Selector{
+				From:        from,
+				To:          to,
+				Include:     []string{"User"},
+				Destination: destination,
+				Inverse:     true,
+			},
+			expectedPath: "selectors_with_include",
+			converters:   []string{"user_convertor.go"},
+		},
+		{
+			name: "With exclude",
+			selector: options.Selector{
+				From:        from,
+				To:          to,
+				Exclude:     []string{"Sess*"},
+				Destination: destinationPath + "/{name}_convertor.go",
+				Inverse:     true,
+			},
+			expectedPath: "selectors_with_exclude",
+			converters:   []string{"account_convertor.go", "user_convertor.go"},
+		},
+		{
+			name: "Into one destination",
+			selector: options.Selector{
+				From:        from,
+				To:          to,
+				Exclude:     []string{"Session"},
+				Destination: destination,
+			},
+			expectedPath: "selectors_into_one_destination",
+			converters:   []string{"user_convertor.go"},
+		},
+		{
+			name: "Not matched models",
+			selector: options.Selector{
+				From:        from,
+				To:          to,
+				Include:     []string{"Unknown*"},
+				Destination: destination,
+			},
+			isError: true,
+		},
+		{
+			name: "Model name without placeholder",
+			selector: options.Selector{
+				From: from,
+				To: options.Model{
+					Source: to.Source,
+					Name:   "UserDTO",
+					Tag:    toModelTag,
+				},
+				Destination: destination,
+			},
+			isError: true,
+		},
+	}
+
+	lg := logger.New()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer clearDestination(t, destinationPath)
+
+			err := MapModels(lg, options.Options{Selectors: []options.Selector{tt.selector}})
+			if tt.isError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			for _, converterName := range tt.converters {
+				actual := readFile(t, converterName)
+				expected := _test_data.MapperExpectedFile(t, tt.expectedPath, converterName)
+				assert.Equal(t, expected, actual)
+			}
+		})
+	}
+}
+
+func Test_MapRecursiveModelsFromOtherPackages(t *testing.T) {
+	opt := options.Option{
+		Destination: destinationPath + "/order.go",
+		Recursive:   true,
+		Inverse:     true,
+		From: options.Model{
+			Source: "../_test_data/mapper/recursive_packages/domain",
+			Name:   "Order",
+			Tag:    modelTag,
+		},
+		To: options.Model{
+			Source: "../_test_data/mapper/recursive_packages/transport",
+			Name:   "Order",
+			Tag:    toModelTag,
+		},
+	}
+
+	lg := logger.New()
+
+	t.Run("not allowed packages", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		err := MapModels(lg, options.Options{Options: []options.Option{opt}})
+		require.Error(t, err)
+	})
+
+	t.Run("allowed packages", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		opt := opt
+		opt.RecursivePackages = []options.PackagePair{
+			{
+				From: options.Package{Source: "../_test_data/mapper/recursive_packages/domain/user"},
+				To:   options.Package{Source: "../_test_data/mapper/recursive_packages/transport/user"},
+			},
+		}
+
+		err := MapModels(lg, options.Options{Options: []options.Option{opt}})
+		require.NoError(t, err)
+
+		for _, converterName := range []string{"order.go", "user_user_converter.go"} {
+			actual := readFile(t, converterName)
+			expected := _test_data.MapperExpectedFile(t, "recursive_packages", converterName)
+			assert.Equal(t, expected, actual)
+		}
+	})
+}
+
+func Test_MapRecursiveContainers(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	opt := options.Option{
+		Destination: destinationPath + "/basket.go",
+		Recursive:   true,
+		Inverse:     true,
+		From: options.Model{
+			Source: "../_test_data/mapper/recursive_containers/domain",
+			Name:   "Basket",
+			Tag:    modelTag,
+		},
+		To: options.Model{
+			Source: "../_test_data/mapper/recursive_containers/dto",
+			Name:   "Basket",
+			Tag:    toModelTag,
+		},
+	}
+
+	err := MapModels(logger.New(), options.Options{Layout: options.LayoutOption, Options: []options.Option{opt}})
+	require.NoError(t, err)
+
+	actual := readFile(t, "basket.go")
+	expected := _test_data.MapperExpectedFile(t, "recursive_containers", "basket.go")
+	assert.Equal(t, expected, actual)
+}
+
+func Test_MapCyclicModels(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	newOption := func(name string) options.Option {
+		return options.Option{
+			Destination: destinationPath + "/" + strings.ToLower(name) + ".go",
+			Recursive:   true,
+			Inverse:     true,
+			From: options.Model{
+				Source: "../_test_data/mapper/cyclic/domain",
+				Name:   name,
+				Tag:    modelTag,
+			},
+			To: options.Model{
+				Source: "../_test_data/mapper/cyclic/dto",
+				Name:   name,
+				Tag:    toModelTag,
+			},
+		}
+	}
+
+	err := MapModels(logger.New(), options.Options{
+		Layout:  options.LayoutOption,
+		Options: []options.Option{newOption("Category"), newOption("User")},
+	})
+	require.NoError(t, err)
+
+	for _, converterName := range []string{"category.go", "user.go"} {
+		actual := readFile(t, converterName)
+		expected := _test_data.MapperExpectedFile(t, "cyclic", converterName)
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func Test_CheckModels(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	opts := options.Options{
+		Options: []options.Option{
+			{
+				Destination: destinationPath + "/basket.go",
+				Recursive:   true,
+				Inverse:     true,
+				From: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/domain",
+					Name:   "Basket",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/dto",
+					Name:   "Basket",
+					Tag:    toModelTag,
+				},
+			},
+		},
+		Layout: options.LayoutOption,
+	}
+
+	lg := logger.New()
+
+	t.Run("not generated", func(t *testing.T) {
+		out := &strings.Builder{}
+		err := CheckModels(lg, opts, out)
+		require.ErrorIs(t, err, ErrStaleConvertors)
+		assert.Contains(t, out.String(), "+++ "+destinationPath+"/basket.go")
+
+		_, err = os.Stat(destinationPath)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	require.NoError(t, MapModels(lg, opts))
+
+	t.Run("up to date", func(t *testing.T) {
+		out := &strings.Builder{}
+		require.NoError(t, CheckModels(lg, opts, out))
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("stale", func(t *testing.T) {
+		actual := readFile(t, "basket.go")
+		stale := strings.Replace(actual, "ConvertDomainItemToDtoItem(item)", "dto.Item{}", 1)
+		require.NoError(t, os.WriteFile(destinationPath+"/basket.go", []byte(stale), 0600))
+
+		out := &strings.Builder{}
+		err := CheckModels(lg, opts, out)
+		require.ErrorIs(t, err, ErrStaleConvertors)
+		assert.Contains(t, out.String(), "-\t\tres := dto.Item{}")
+		assert.Contains(t, out.String(), "+\t\tres := ConvertDomainItemToDtoItem(item)")
+		assert.Equal(t, stale, readFile(t, "basket.go"))
+	})
+
+	require.NoError(t, MapModels(lg, opts))
+
+	t.Run("without option", func(t *testing.T) {
+		orphan := "// Code generated by datamapper.\npackage mapper\n"
+		require.NoError(t, os.WriteFile(destinationPath+"/orphan.go", []byte(orphan), 0600))
+		defer func() {
+			require.NoError(t, os.Remove(destinationPath+"/orphan.go"))
+		}()
+
+		out := &strings.Builder{}
+		err := CheckModels(lg, opts, out)
+		require.ErrorIs(t, err, ErrStaleConvertors)
+		assert.Contains(t, out.String(), "orphan.go has no option")
+	})
+
+	t.Run("shared destination package", func(t *testing.T) {
+		other := options.Options{
+			Options: []options.Option{
+				{
+					Destination: destinationPath + "/category.go",
+					Recursive:   true,
+					Inverse:     true,
+					From: options.Model{
+						Source: "../_test_data/mapper/cyclic/domain",
+						Name:   "Category",
+						Tag:    modelTag,
+					},
+					To: options.Model{
+						Source: "../_test_data/mapper/cyclic/dto",
+						Name:   "Category",
+						Tag:    toModelTag,
+					},
+				},
+			},
+			Layout: options.LayoutOption,
+		}
+		require.NoError(t, MapModels(lg, other))
+
+		out := &strings.Builder{}
+		err := CheckModels(lg, opts, out)
+		require.ErrorIs(t, err, ErrStaleConvertors)
+		assert.Contains(t, out.String(), "category.go has no option")
+
+		opts.SharedDestinations = []string{destinationPath + "/category.go"}
+		other.SharedDestinations = []string{destinationPath + "/basket*.go"}
+		for _, config := range []options.Options{opts, other} {
+			out = &strings.Builder{}
+			require.NoError(t, CheckModels(lg, config, out))
+			assert.Empty(t, out.String())
+		}
+	})
+}
+
+func Test_DryRunModels(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	opt := options.Option{
+		Destination: destinationPath + "/basket.go",
+		Recursive:   true,
+		Inverse:     true,
+		From: options.Model{
+			Source: "../_test_data/mapper/recursive_containers/domain",
+			Name:   "Basket",
+			Tag:    modelTag,
+		},
+		To: options.Model{
+			Source: "../_test_data/mapper/recursive_containers/dto",
+			Name:   "Basket",
+			Tag:    toModelTag,
+		},
+	}
+
+	opts := options.Options{
+		Options: []options.Option{opt},
+		Layout:  options.LayoutOption,
+		DryRun:  true,
+	}
+
+	lg := logger.New()
+
+	t.Run("new file", func(t *testing.T) {
+		out := &strings.Builder{}
+		require.NoError(t, mapModels(lg, opts, out))
+		assert.Contains(t, out.String(), "new       "+destinationPath+"/basket.go")
+		assert.Contains(t, out.String(), "+func ConvertDomainBasketToDtoBasket")
+
+		_, err := os.Stat(destinationPath)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("unchanged file", func(t *testing.T) {
+		writeOpts := opts
+		writeOpts.DryRun = false
+		require.NoError(t, MapModels(lg, writeOpts))
+
+		out := &strings.Builder{}
+		require.NoError(t, mapModels(lg, opts, out))
+		assert.Equal(t, "datamapper dry run: 1 files\n  unchanged "+destinationPath+"/basket.go\n", out.String())
+	})
+}
+
+func Test_MapModelsToStdout(t *testing.T) {
+	opt := options.Option{
+		Destination: options.StdoutDestination,
+		Recursive:   true,
+		Inverse:     true,
+		From: options.Model{
+			Source: "../_test_data/mapper/recursive_containers/domain",
+			Name:   "Basket",
+			Tag:    modelTag,
+		},
+		To: options.Model{
+			Source: "../_test_data/mapper/recursive_containers/dto",
+			Name:   "Basket",
+			Tag:    toModelTag,
+		},
+	}
+
+	lg := logger.New()
+
+	t.Run("one option", func(t *testing.T) {
+		out := &strings.Builder{}
+		require.NoError(t, mapModels(lg, options.Options{Options: []options.Option{opt}}, out))
+
+		expected := _test_data.MapperExpectedFile(t, "recursive_containers", "basket.go")
+		assert.Equal(t, expected, out.String())
+
+		_, err := os.Stat(options.StdoutDestination)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("many options", func(t *testing.T) {
+		err := mapModels(lg, options.Options{Options: []options.Option{opt, opt}}, &strings.Builder{})
+		require.ErrorIs(t, err, ErrStdoutOption)
+	})
+}
+
+func Test_MapModelsInParallel(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	newOption := func(source, fromName, toName string) options.Option {
+		return options.Option{
+			Destination: destinationPath + "/convertors.go",
+			Recursive:   true,
+			Inverse:     true,
+			From: options.Model{
+				Source: "../_test_data/mapper/" + source + "/domain",
+				Name:   fromName,
+				Tag:    modelTag,
+			},
+			To: options.Model{
+				Source: "../_test_data/mapper/" + source + "/dto",
+				Name:   toName,
+				Tag:    toModelTag,
+			},
+		}
+	}
+
+	opts := options.Options{
+		Layout: options.LayoutOption,
+		Options: []options.Option{
+			newOption("cyclic", "Category", "Category"),
+			newOption("selectors", "User", "UserDTO"),
+			newOption("recursive_containers", "Basket", "Basket"),
+			newOption("cyclic", "User", "User"),
+			newOption("selectors", "Account", "AccountDTO"),
+			newOption("cyclic", "Team", "Team"),
+		},
+	}
+
+	lg := logger.New()
+
+	opts.Parallel = 1
+	serial, err := Generate(context.Background(), lg, opts)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		opts.Parallel = 4
+		parallel, err := Generate(context.Background(), lg, opts)
+		require.NoError(t, err)
+		assert.Equal(t, serial, parallel)
+	}
+}
+
+func Test_MapModelsWithCache(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	dest := destinationPath + "/cached_basket.go"
+	opts := options.Options{
+		CacheDir: t.TempDir(),
+		Layout:   options.LayoutOption,
+		Options: []options.Option{
+			{
+				Destination: dest,
+				Recursive:   true,
+				Inverse:     true,
+				From: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/domain",
+					Name:   "Basket",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/dto",
+					Name:   "Basket",
+					Tag:    toModelTag,
+				},
+			},
+		},
+	}
+
+	lg := logger.New()
+	modified := func() time.Time {
+		info, err := os.Stat(dest)
+		require.NoError(t, err)
+		return info.ModTime()
+	}
+
+	require.NoError(t, mapModels(lg, opts, &strings.Builder{}))
+	expected := _test_data.MapperExpectedFile(t, "recursive_containers", "basket.go")
+	actual, err := os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(dest, past, past))
+
+	// inputs and config are not changed, so generation is skipped
+	require.NoError(t, mapModels(lg, opts, &strings.Builder{}))
+	assert.Equal(t, past, modified())
+
+	opts.NoCache = true
+	require.NoError(t, mapModels(lg, opts, &strings.Builder{}))
+	assert.NotEqual(t, past, modified())
+
+	// edited destination is regenerated
+	require.NoError(t, os.WriteFile(dest, []byte("package mapper\n"), 0600))
+	opts.NoCache = false
+	require.NoError(t, mapModels(lg, opts, &strings.Builder{}))
+	actual, err = os.ReadFile(dest)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(actual))
+}
+
+func Test_ExplainModels(t *testing.T) {
+	opts := options.Options{
+		Explain: true,
+		Options: []options.Option{
+			{
+				Destination: destinationPath + "/basket.go",
+				Recursive:   true,
+				Inverse:     true,
+				From: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/domain",
+					Name:   "Basket",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/dto",
+					Name:   "Basket",
+					Tag:    toModelTag,
+				},
+			},
+		},
+	}
+
+	lg := logger.New()
+
+	t.Run("json", func(t *testing.T) {
+		opts.Format = options.FormatJSON
+		out := &strings.Builder{}
+		require.NoError(t, mapModels(lg, opts, out))
+
+		var explanations []generator.ConvertorExplanation
+		require.NoError(t, json.Unmarshal([]byte(out.String()), &explanations))
+
+		var names []string
+		for _, explanation := range explanations {
+			names = append(names, explanation.Name)
+		}
+
+		assert.Equal(t, []string{
+			"ConvertDomainItemToDtoItem",
+			"ConvertDtoItemToDomainItem",
+			"ConvertDomainBasketToDtoBasket",
+			"ConvertDtoBasketToDomainBasket",
+		}, names)
+
+		price := explanations[0].Fields[1]
+		assert.Equal(t, "Price", price.ToField)
+		assert.Equal(t, generator.NeedCallConversionFunctionRule, price.Rule)
+		require.NotNil(t, price.Function)
+		assert.Equal(t, "ConvertOrderedToOrdered", price.Function.Name)
+		assert.Equal(t, "github.com/underbek/datamapper/converts", price.Function.Package)
+		assert.Contains(t, price.Function.Location, "converts/simple.go:")
+
+		archived := explanations[2].Fields[5]
+		assert.Equal(t, "Archived", archived.ToField)
+		assert.Equal(t, generator.NeedRangeBySlice, archived.Rule)
+		assert.Equal(t, generator.NeedCallConversionFunctionRule, archived.ItemRule)
+		assert.Equal(t, "nil returns error", archived.Pointer)
+		assert.True(t, archived.WithError)
+		require.NotNil(t, archived.Function)
+		assert.True(t, archived.Function.Generated)
+
+		_, err := os.Stat(destinationPath + "/basket.go")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("table", func(t *testing.T) {
+		opts.Format = options.FormatTable
+		out := &strings.Builder{}
+		require.NoError(t, mapModels(lg, opts, out))
+
+		assert.Contains(t, out.String(), "ConvertDomainBasketToDtoBasket: domain.Basket -> dto.Basket (returns error)\n")
+		assert.Contains(t, out.String(), "TO FIELD")
+		assert.Contains(t, out.String(), "NeedRangeBySlice(NeedCallConversionFunctionRule)")
+		assert.Contains(t, out.String(), "generated")
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		opts.Format = "xml"
+		require.ErrorIs(t, mapModels(lg, opts, &strings.Builder{}), ErrUnknownFormat)
+	})
+}
+
+func Test_ListFunctions(t *testing.T) {
+	opts := options.Options{
+		ListFunctions: true,
+		FunctionsType: "uuid.UUID",
+		ConversionFunctions: []options.ConversionFunction{
+			{Source: customCFPath},
+			{Source: otherCFPath},
+		},
+	}
+
+	lg := logger.New()
+
+	t.Run("json", func(t *testing.T) {
+		opts.Format = options.FormatJSON
+		out := &strings.Builder{}
+		require.NoError(t, mapModels(lg, opts, out))
+
+		var infos []FunctionInfo
+		require.NoError(t, json.Unmarshal([]byte(out.String()), &infos))
+		require.NotEmpty(t, infos)
+
+		for _, info := range infos {
+			assert.True(t, info.From == "uuid.UUID" || info.To == "uuid.UUID", info)
+		}
+
+		assert.Contains(t, infos, FunctionInfo{
+			From:      "string",
+			To:        "uuid.UUID",
+			Function:  "converts.ConvertStringToUUID",
+			Package:   "github.com/underbek/datamapper/converts",
+			WithError: true,
+			Source:    InternalFunctionsSource,
+		})
+
+		assert.Contains(t, infos, FunctionInfo{
+			From:      "int64",
+			To:        "uuid.UUID",
+			Function:  "other_convertors.CustomIntegerToUUID[int64]",
+			Package:   "github.com/underbek/datamapper/_test_data/mapper/other_convertors",
+			Generic:   true,
+			Source:    otherCFPath,
+			Overrides: "convertors.CustomIntegerToUUID[int64] from " + customCFPath,
+		})
+
+		assert.Contains(t, infos, FunctionInfo{
+			From:     "uuid.UUID",
+			To:       "int64",
+			Function: "convertors.CustomUUIDToInteger[int64]",
+			Package:  "github.com/underbek/datamapper/_test_data/mapper/convertors",
+			Generic:  true,
+			Source:   customCFPath,
+		})
+	})
+
+	t.Run("table", func(t *testing.T) {
+		opts.Format = options.FormatTable
+		out := &strings.Builder{}
+		require.NoError(t, mapModels(lg, opts, out))
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		assert.Equal(t, []string{"FROM", "TO", "FUNCTION", "PACKAGE", "ERROR", "SOURCE", "OVERRIDES"},
+			strings.Fields(lines[0]))
+		assert.Contains(t, out.String(), "other_convertors.CustomIntegerToUUID[int8]")
+		assert.NotContains(t, out.String(), "ConvertNumericToString")
+	})
+}
+
+func Test_MapUnconvertibleFields(t *testing.T) {
+	opt := options.Option{
+		Destination: destinationPath + "/order.go",
+		Recursive:   true,
+		Inverse:     true,
+		From: options.Model{
+			Source: "../_test_data/mapper/unconvertible/domain",
+			Name:   "Order",
+			Tag:    modelTag,
+		},
+		To: options.Model{
+			Source: "../_test_data/mapper/unconvertible/transport",
+			Name:   "Order",
+			Tag:    toModelTag,
+		},
+	}
+
+	type fieldError struct {
+		from, to         string
+		fromLine, toLine int
+	}
+
+	tests := []struct {
+		name      string
+		recursive bool
+		expected  []fieldError
+	}{
+		{
+			name:      "recursive",
+			recursive: true,
+			expected: []fieldError{
+				{from: "Item.Price", to: "Item.Price", fromLine: 13, toLine: 5},
+				{from: "Item.Price", to: "Item.Price", fromLine: 5, toLine: 13},
+				{from: "Order.Status", to: "Order.Status", fromLine: 18, toLine: 10},
+				{from: "Order.Created", to: "Order.Created", fromLine: 20, toLine: 12},
+				{from: "Order.Status", to: "Order.Status", fromLine: 10, toLine: 18},
+				{from: "Order.Created", to: "Order.Created", fromLine: 12, toLine: 20},
+			},
+		},
+		{
+			name: "without recursive",
+			expected: []fieldError{
+				{from: "Order.Status", to: "Order.Status", fromLine: 18, toLine: 10},
+				{from: "Order.Item", to: "Order.Item", fromLine: 19, toLine: 11},
+				{from: "Order.Created", to: "Order.Created", fromLine: 20, toLine: 12},
+				{from: "Order.Status", to: "Order.Status", fromLine: 10, toLine: 18},
+				{from: "Order.Item", to: "Order.Item", fromLine: 11, toLine: 19},
+				{from: "Order.Created", to: "Order.Created", fromLine: 12, toLine: 20},
+			},
+		},
+	}
+
+	lg := logger.New()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer clearDestination(t, destinationPath)
+
+			opt := opt
+			opt.Recursive = tt.recursive
+
+			err := MapModels(lg, options.Options{
+				ConversionFunctions: []options.ConversionFunction{
+					{Source: "../_test_data/mapper/unconvertible/convertors"},
+				},
+				Options: []options.Option{opt},
+			})
+			var fieldsErr *generator.FieldsError
+			require.ErrorAs(t, err, &fieldsErr)
+
+			actual := make([]fieldError, 0, len(fieldsErr.Errors))
+			for _, fieldErr := range fieldsErr.Errors {
+				actual = append(actual, fieldError{
+					from:     fieldErr.FromPath(),
+					to:       fieldErr.ToPath(),
+					fromLine: fieldErr.FromPosition.Line,
+					toLine:   fieldErr.ToPosition.Line,
+				})
+
+				assert.Equal(t, "models.go", filepath.Base(fieldErr.FromPosition.Filename))
+				assert.Contains(t, err.Error(), fieldErr.Error())
+			}
+
+			assert.Equal(t, tt.expected, actual)
+
+			var findErr *generator.FindFieldsPairError
+			require.ErrorAs(t, err, &findErr)
+			assert.Equal(t, fieldsErr.Errors[0].FromPosition, findErr.FromPosition)
+
+			if tt.recursive {
+				require.Len(t, findErr.Candidates, 1)
+				assert.Equal(t, "ConvertMoneyToFloat", findErr.Candidates[0].Name)
+				assert.Contains(t, err.Error(),
+					"not found convertor function for types "+
+						"github.com/underbek/datamapper/_test_data/mapper/unconvertible/domain.Money -> float64 "+
+						"by field github.com/underbek/datamapper/_test_data/mapper/unconvertible/domain.Item.Price "+
+						"(",
+				)
+				assert.Contains(t, err.Error(), "unconvertible/domain/models.go:13:2) -> ")
+				assert.Contains(t, err.Error(),
+					"candidates: convertors.ConvertMoneyToFloat(*domain.Money) (float64, error)")
+			}
+
+			_, err = os.Stat(destinationPath + "/order.go")
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func Test_ScaffoldMissingFunctions(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	stubsPath := destinationPath + "/stubs"
+	opts := options.Options{
+		ScaffoldMissing: stubsPath,
+		Options: []options.Option{
+			{
+				Destination: destinationPath + "/order.go",
+				Recursive:   true,
+				Inverse:     true,
+				From: options.Model{
+					Source: "../_test_data/mapper/unconvertible/domain",
+					Name:   "Order",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/unconvertible/transport",
+					Name:   "Order",
+					Tag:    toModelTag,
+				},
+			},
+		},
+	}
+
+	lg := logger.New()
+	expected := _test_data.MapperExpectedFile(t, "scaffold_missing", StubsFileName)
+
+	require.NoError(t, MapModels(lg, opts))
+	assert.Equal(t, expected, readFile(t, "stubs/"+StubsFileName))
+	assert.Contains(t, readFile(t, "order.go"), "stubs.ConvertDomainStatusToString(from.Status)")
+
+	t.Run("stubs exist", func(t *testing.T) {
+		require.NoError(t, MapModels(lg, opts))
+		assert.Equal(t, expected, readFile(t, "stubs/"+StubsFileName))
+	})
+}
+
+func Test_MapRoundTripTests(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	opts := options.Options{
+		Options: []options.Option{
+			{
+				Destination:    destinationPath + "/product.go",
+				Recursive:      true,
+				Inverse:        true,
+				RoundTripTests: true,
+				From: options.Model{
+					Source: "../_test_data/mapper/round_trip/domain",
+					Name:   "Product",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/round_trip/dto",
+					Name:   "Product",
+					Tag:    toModelTag,
+				},
+			},
+		},
+	}
+
+	lg := logger.New()
+	require.NoError(t, MapModels(lg, opts))
+
+	assert.Equal(t, _test_data.MapperExpectedFile(t, "round_trip", "product_test.go"), readFile(t, "product_test.go"))
+	assert.Equal(t,
+		_test_data.MapperExpectedFile(t, "round_trip", "item_converter_test.go"),
+		readFile(t, "item_converter_test.go"),
+	)
+
+	t.Run("lossy functions", func(t *testing.T) {
+		opts.LossyFunctions = []string{"ConvertNumericToString"}
+		require.NoError(t, MapModels(lg, opts))
+
+		content := readFile(t, "product_test.go")
+		assert.Contains(t, content, "res.Price != from.Price")
+		assert.NotContains(t, content, "res.Code")
+	})
+}
+
+func Test_MapDiff(t *testing.T) {
+	lg := logger.New()
+
+	t.Run("Recursive with inverse", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		opts := options.Options{
+			Options: []options.Option{
+				{
+					Destination: destinationPath + "/product.go",
+					Recursive:   true,
+					Inverse:     true,
+					Diff:        true,
+					From: options.Model{
+						Source: "../_test_data/mapper/round_trip/domain",
+						Name:   "Product",
+						Tag:    modelTag,
+					},
+					To: options.Model{
+						Source: "../_test_data/mapper/round_trip/dto",
+						Name:   "Product",
+						Tag:    toModelTag,
+					},
+				},
+			},
+		}
+
+		require.NoError(t, MapModels(lg, opts))
+
+		for _, file := range []string{"product.go", "item_converter.go"} {
+			assert.Equal(t, _test_data.MapperExpectedFile(t, "with_diff", file), readFile(t, file))
+		}
+	})
+
+	t.Run("With dash and pointers", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		opts := options.Options{
+			ConversionFunctions: []options.ConversionFunction{
+				{Source: customCFPath},
+				{Source: "../_test_data/mapper/with_dash_and_pointers/convertors/additional_convertor.go"},
+			},
+			Options: []options.Option{
+				{
+					Destination: destination,
+					Inverse:     true,
+					Diff:        true,
+					From: options.Model{
+						Source: "../_test_data/mapper/with_dash_and_pointers/domain",
+						Name:   "*Order",
+						Tag:    modelTag,
+					},
+					To: options.Model{
+						Source: "../_test_data/mapper/with_dash_and_pointers/dao",
+						Name:   "OrderData",
+						Tag:    "db",
+						Alias:  "db",
+					},
+				},
+			},
+		}
+
+		require.NoError(t, MapModels(lg, opts))
+		assert.Equal(t, _test_data.MapperExpected(t, "with_dash_and_pointers_diff"), readActual(t))
+	})
+}
+
+func Test_MapApply(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	opts := options.Options{
+		ConversionFunctions: []options.ConversionFunction{
+			{Source: customCFPath},
+			{Source: "../_test_data/mapper/with_dash_and_pointers/convertors/additional_convertor.go"},
+		},
+		Options: []options.Option{
+			{
+				Destination: destination,
+				Inverse:     true,
+				Apply:       true,
+				From: options.Model{
+					Source: "../_test_data/mapper/with_dash_and_pointers/domain",
+					Name:   "*Order",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/with_dash_and_pointers/dao",
+					Name:   "OrderData",
+					Tag:    "db",
+					Alias:  "db",
+				},
+			},
+		},
+	}
+
+	require.NoError(t, MapModels(logger.New(), opts))
+	assert.Equal(t, _test_data.MapperExpected(t, "with_apply"), readActual(t))
+}
+
+func Test_MapInPlace(t *testing.T) {
+	defer clearDestination(t, destinationPath)
+
+	opts := options.Options{
+		ConversionFunctions: []options.ConversionFunction{
+			{Source: customCFPath},
+			{Source: "../_test_data/mapper/with_dash_and_pointers/convertors/additional_convertor.go"},
+		},
+		Options: []options.Option{
+			{
+				Destination: destination,
+				Inverse:     true,
+				InPlace:     true,
+				From: options.Model{
+					Source: "../_test_data/mapper/with_dash_and_pointers/domain",
+					Name:   "*Order",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/with_dash_and_pointers/dao",
+					Name:   "OrderData",
+					Tag:    "db",
+					Alias:  "db",
+				},
+			},
+		},
+	}
+
+	require.NoError(t, MapModels(logger.New(), opts))
+	assert.Equal(t, _test_data.MapperExpected(t, "with_in_place"), readActual(t))
+	assert.Equal(t,
+		_test_data.MapperExpectedFile(t, "with_in_place", "expected_test.go"),
+		readFile(t, "user_convertor_test.go"),
+	)
+}
+
+func Test_MapMerge(t *testing.T) {
+	const source = "../_test_data/mapper/merge/domain"
+
+	user := options.Model{Source: source, Name: "User", Tag: modelTag}
+	account := options.Model{Source: source, Name: "*Account", Tag: "profile", Param: "account"}
+
+	newOptions := func(sources ...options.Model) options.Options {
+		return options.Options{
+			Layout: options.LayoutOption,
+			Options: []options.Option{
+				{
+					Destination: destination,
+					Recursive:   true,
+					FromModels:  sources,
+					To: options.Model{
+						Source: "../_test_data/mapper/merge/dto",
+						Name:   "Profile",
+						Tag:    "json",
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions(user, account)))
+		assert.Equal(t, _test_data.MapperExpected(t, "merge"), readActual(t))
+	})
+
+	t.Run("Precedence by order of sources", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions(account, user)))
+
+		content := readActual(t)
+		assert.Contains(t, content, "(account *domain.Account, user domain.User)")
+		assert.Contains(t, content, "converts.ConvertStringToSigned[int64](account.UserID)")
+		assert.Contains(t, content, "Name:      account.Name,")
+		assert.Contains(t, content, "Address:   ConvertDomainAddressToDtoAddress(user.Address),")
+	})
+
+	tests := []struct {
+		name   string
+		opts   func() options.Options
+		target error
+	}{
+		{
+			name: "From and from models",
+			opts: func() options.Options {
+				opts := newOptions(user, account)
+				opts.Options[0].From = user
+				return opts
+			},
+			target: ErrMergeOption,
+		},
+		{
+			name: "Inverse",
+			opts: func() options.Options {
+				opts := newOptions(user, account)
+				opts.Options[0].Inverse = true
+				return opts
+			},
+			target: ErrMergeOption,
+		},
+		{
+			name: "Duplicate params",
+			opts: func() options.Options {
+				duplicate := account
+				duplicate.Param = "user"
+				return newOptions(user, duplicate)
+			},
+			target: generator.ErrMergeParam,
+		},
+		{
+			name: "Param shadows package",
+			opts: func() options.Options {
+				shadow := account
+				shadow.Param = "dto"
+				return newOptions(user, shadow)
+			},
+			target: generator.ErrMergeParam,
+		},
+		{
+			name: "Constants",
+			opts: func() options.Options {
+				opts := newOptions(user, account)
+				opts.Options[0].Constants = map[string]string{"Name": `"name"`}
+				return opts
+			},
+			target: ErrMergeOption,
+		},
+		{
+			name: "Compute option of tag",
+			opts: func() options.Options {
+				opts := newOptions(options.Model{
+					Source: "../_test_data/mapper/computed/domain",
+					Name:   "User",
+					Tag:    modelTag,
+				})
+				opts.Options[0].To = options.Model{Source: "../_test_data/mapper/computed/dto", Name: "Profile", Tag: "json"}
+				return opts
+			},
+			target: ErrMergeOption,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer clearDestination(t, destinationPath)
+
+			err := MapModels(logger.New(), tt.opts())
+			assert.ErrorIs(t, err, tt.target)
+		})
+	}
+}
+
+func Test_MapSplit(t *testing.T) {
+	const source = "../_test_data/mapper/split/domain"
+
+	newOptions := func() options.Options {
+		return options.Options{
+			Layout: options.LayoutOption,
+			Options: []options.Option{
+				{
+					Destination: destination,
+					Recursive:   true,
+					From: options.Model{
+						Source: "../_test_data/mapper/split/dto",
+						Name:   "*CreateUserRequest",
+						Tag:    "json",
+					},
+					ToModels: []options.Model{
+						{Source: source, Name: "User", Tag: modelTag},
+						{Source: source, Name: "*Address", Tag: "addr"},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions()))
+		assert.Equal(t, _test_data.MapperExpected(t, "split"), readActual(t))
+	})
+
+	t.Run("Strict", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		opts := newOptions()
+		opts.Options[0].Strict = true
+
+		err := MapModels(logger.New(), opts)
+		require.ErrorIs(t, err, ErrNotConsumedFields)
+		assert.Contains(t, err.Error(), "CreateUserRequest.Password (tag password)")
+	})
+
+	tests := []struct {
+		name   string
+		opts   func() options.Options
+		target error
+	}{
+		{
+			name: "To and to models",
+			opts: func() options.Options {
+				opts := newOptions()
+				opts.Options[0].To = options.Model{Source: source, Name: "User", Tag: modelTag}
+				return opts
+			},
+			target: ErrSplitOption,
+		},
+		{
+			name: "With slice",
+			opts: func() options.Options {
+				opts := newOptions()
+				opts.Options[0].WithSlice = true
+				return opts
+			},
+			target: ErrSplitOption,
+		},
+		{
+			name: "Strict without to models",
+			opts: func() options.Options {
+				opts := newOptions()
+				opts.Options[0].ToModels = nil
+				opts.Options[0].To = options.Model{Source: source, Name: "User", Tag: modelTag}
+				opts.Options[0].Strict = true
+				return opts
+			},
+			target: ErrSplitOption,
+		},
+		{
+			name: "Defaults",
+			opts: func() options.Options {
+				opts := newOptions()
+				opts.Options[0].Defaults = map[string]string{"Name": `"name"`}
+				return opts
+			},
+			target: ErrSplitOption,
+		},
+		{
+			name: "Const option of tag",
+			opts: func() options.Options {
+				opts := newOptions()
+				opts.Options[0].From = options.Model{Source: "../_test_data/mapper/values/domain", Name: "User", Tag: modelTag}
+				opts.Options[0].ToModels = []options.Model{
+					{Source: "../_test_data/mapper/values/dto", Name: "User", Tag: "json"},
+				}
+				return opts
+			},
+			target: ErrSplitOption,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer clearDestination(t, destinationPath)
+
+			err := MapModels(logger.New(), tt.opts())
+			assert.ErrorIs(t, err, tt.target)
+		})
+	}
+}
+
+func Test_MapComputed(t *testing.T) {
+	const computedPackage = "github.com/underbek/datamapper/_test_data/mapper/computed/computed"
+
+	newOptions := func(computed map[string]string) options.Options {
+		return options.Options{
+			Layout: options.LayoutOption,
+			ComputedFunctions: []options.ConversionFunction{
+				{Source: "../_test_data/mapper/computed/computed"},
+			},
+			Options: []options.Option{
+				{
+					Destination: destination,
+					From: options.Model{
+						Source: "../_test_data/mapper/computed/domain",
+						Name:   "User",
+						Tag:    modelTag,
+					},
+					To: options.Model{
+						Source: "../_test_data/mapper/computed/dto",
+						Name:   "Profile",
+						Tag:    "json",
+					},
+					Computed: computed,
+				},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions(map[string]string{"Initials": "Initials"})))
+		assert.Equal(t, _test_data.MapperExpected(t, "computed"), readActual(t))
+	})
+
+	t.Run("Config overrides tag", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions(map[string]string{
+			"FullName": computedPackage + ".Initials",
+		})))
+		assert.Contains(t, readActual(t), "FullName: computed.Initials(from),")
+	})
+
+	t.Run("Function of conversion functions source", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		opts := newOptions(map[string]string{"Initials": "Initials"})
+		opts.ConversionFunctions, opts.ComputedFunctions = opts.ComputedFunctions, nil
+
+		require.NoError(t, MapModels(logger.New(), opts))
+		assert.Equal(t, _test_data.MapperExpected(t, "computed"), readActual(t))
+	})
+
+	t.Run("Functions with the same signature", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions(map[string]string{
+			"FullName": "Initials",
+			"Initials": "FullName",
+		})))
+		assert.Contains(t, readActual(t), "FullName: computed.Initials(from),")
+		assert.Contains(t, readActual(t), "Initials: computed.FullName(from),")
+	})
+
+	t.Run("Computed functions are not conversion functions", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		opts := newOptions(nil)
+		opts.ConversionFunctions = opts.ComputedFunctions
+		opts.Options[0].From.Name = "Account"
+		opts.Options[0].To.Name = "Account"
+
+		err := MapModels(logger.New(), opts)
+		var fieldsErr *generator.FieldsError
+		require.ErrorAs(t, err, &fieldsErr)
+		require.Len(t, fieldsErr.Errors, 1)
+		assert.Equal(t, "Account.Owner", fieldsErr.Errors[0].ToPath())
+	})
+
+	tests := []struct {
+		name     string
+		computed map[string]string
+	}{
+		{
+			name:     "Unknown function",
+			computed: map[string]string{"Initials": "Nickname"},
+		},
+		{
+			name:     "Different result type",
+			computed: map[string]string{"Age": "FullName"},
+		},
+		{
+			name:     "Function of other package",
+			computed: map[string]string{"Initials": "github.com/underbek/datamapper/converts.Initials"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer clearDestination(t, destinationPath)
+
+			err := MapModels(logger.New(), newOptions(tt.computed))
+			assert.ErrorIs(t, err, ErrNotFoundComputed)
+		})
+	}
+}
+
+func Test_MapValues(t *testing.T) {
+	newOptions := func(constants, defaults map[string]string) options.Options {
+		return options.Options{
+			Layout: options.LayoutOption,
+			Options: []options.Option{
+				{
+					Destination: destination,
+					From: options.Model{
+						Source: "../_test_data/mapper/values/domain",
+						Name:   "User",
+						Tag:    modelTag,
+					},
+					To: options.Model{
+						Source: "../_test_data/mapper/values/dto",
+						Name:   "User",
+						Tag:    "json",
+					},
+					Constants: constants,
+					Defaults:  defaults,
+				},
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions(
+			map[string]string{"Source": `"api"`, "Kind": `"admin"`},
+			nil,
+		)))
+		assert.Equal(t, _test_data.MapperExpected(t, "values"), readActual(t))
+	})
+
+	t.Run("Config overrides tag", func(t *testing.T) {
+		defer clearDestination(t, destinationPath)
+
+		require.NoError(t, MapModels(logger.New(), newOptions(
+			map[string]string{"Version": "3"},
+			map[string]string{"Age": "21"},
+		)))
+
+		content := readActual(t)
+		assert.Contains(t, content, "Version: 3,")
+		assert.Contains(t, content, "defaultAge = 21")
+		assert.Contains(t, content, `"admin, owner",`)
+	})
+
+	tests := []struct {
+		name      string
+		constants map[string]string
+		defaults  map[string]string
+		target    error
+	}{
+		{
+			name:      "Constant of wrong type",
+			constants: map[string]string{"Version": `"2"`},
+			target:    parser.ErrInvalidValue,
+		},
+		{
+			name:     "Default of wrong type",
+			defaults: map[string]string{"Name": "false"},
+			target:   parser.ErrInvalidValue,
+		},
+		{
+			name:      "Constant with default",
+			constants: map[string]string{"Name": `"name"`},
+			target:    ErrFieldValue,
+		},
+		{
+			name:     "Default of not pointer field",
+			defaults: map[string]string{"ID": `"0"`},
+			target:   ErrFieldValue,
+		},
+		{
+			name:     "Default without from field",
+			defaults: map[string]string{"Source": `"web"`},
+			target:   ErrFieldValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer clearDestination(t, destinationPath)
+
+			err := MapModels(logger.New(), newOptions(tt.constants, tt.defaults))
+			assert.ErrorIs(t, err, tt.target)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/underbek/datamapper/generator"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+var ErrMergeOption = errors.New("merge option error")
+
+// mergeSource is a from model of merge convertor with models of its package
+type mergeSource struct {
+	generator.Source
+	structs map[string]models.Struct
+}
+
+// mapMergeOption maps from models of option into one to model by merge convertor
+func (g generation) mapMergeOption(
+	lg logger.Logger,
+	session *parser.Session,
+	opt options.Option,
+	funcs models.Functions,
+	readOnly bool,
+) (models.Functions, optionResult) {
+	if err := checkMergeOption(opt); err != nil {
+		return funcs, optionResult{err: err}
+	}
+
+	aliases := make(map[string]string)
+	sources := make([]mergeSource, 0, len(opt.FromModels))
+	for _, model := range opt.FromModels {
+		from, structs, err := parseOptionModel(lg, session, model, "source")
+		if err != nil {
+			return funcs, optionResult{err: err}
+		}
+
+		param := model.Param
+		if param == "" {
+			param = defaultParam(from.Type.Name)
+		}
+
+		aliases[from.Type.Package.Path] = model.Alias
+		sources = append(sources, mergeSource{
+			Source:  generator.Source{Model: from, Tag: model.Tag, Param: param},
+			structs: structs,
+		})
+	}
+
+	to, toStructs, err := parseOptionModel(lg, session, opt.To, "to")
+	if err != nil {
+		return funcs, optionResult{err: err}
+	}
+	aliases[to.Type.Package.Path] = opt.To.Alias
+
+	recursivePackages, err := parseRecursivePackages(lg, session, opt.RecursivePackages, aliases)
+	if err != nil {
+		return funcs, optionResult{err: fmt.Errorf("parse recursive packages error: %w", err)}
+	}
+
+	maps.Copy(aliases, g.cfAliases)
+
+	m := g.newModelMapper(lg, session, opt, aliases, recursivePackages, readOnly)
+	res, err := m.mapRoot(func() (models.Functions, error) {
+		return m.mapMergeModels(sources, to, opt.Destination, copyFunctions(funcs), toStructs)
+	})
+	if err != nil {
+		return funcs, optionResult{err: err}
+	}
+
+	return res, optionResult{convertors: m.convertors}
+}
+
+// parseOptionModel returns model of option with models of its package, kind of model is used by errors
+func parseOptionModel(lg logger.Logger, session *parser.Session, model options.Model, kind string,
+) (models.Struct, map[string]models.Struct, error) {
+	structs, err := session.ParseModelsByPackage(lg, model.Source)
+	if err != nil {
+		return models.Struct{}, nil, fmt.Errorf("parse models error: %w", err)
+	}
+
+	name, isPointer := parseModelName(model.Name)
+	res, ok := structs[name]
+	if !ok {
+		return models.Struct{}, nil, fmt.Errorf("%w: %s model %s from %s", ErrNotFoundStruct, kind, model.Name, model.Source)
+	}
+	res.Type.Pointer = isPointer
+
+	return res, structs, nil
+}
+
+// checkMergeOption checks that option with from models does not use flags of single from model
+func checkMergeOption(opt options.Option) error {
+	if opt.From.Name != "" {
+		return fmt.Errorf("%w: from and from-models cannot be set together", ErrMergeOption)
+	}
+
+	if len(opt.ToModels) != 0 {
+		return fmt.Errorf("%w: from-models and to-models cannot be set together", ErrMergeOption)
+	}
+
+	if flag, ok := pairFlag(opt); ok {
+		return fmt.Errorf("%w: %s is not supported with from-models", ErrMergeOption, flag)
+	}
+
+	if binding, ok := fieldsBinding(opt); ok {
+		return fmt.Errorf("%w: %s is not supported with from-models", ErrMergeOption, binding)
+	}
+
+	return nil
+}
+
+// pairFlag returns enabled flag of option which is supported only by convertors of one from and one to models
+func pairFlag(opt options.Option) (string, bool) {
+	flags := []struct {
+		name    string
+		enabled bool
+	}{
+		{"inverse", opt.Inverse},
+		{"with-slice", opt.WithSlice},
+		{"round-trip-tests", opt.RoundTripTests},
+		{"diff", opt.Diff},
+		{"apply", opt.Apply},
+		{"in-place", opt.InPlace},
+	}
+
+	for _, flag := range flags {
+		if flag.enabled {
+			return flag.name, true
+		}
+	}
+
+	return "", false
+}
+
+// fieldsBinding returns used bindings of fields of option which are supported only by convertors
+// of one from and one to models
+func fieldsBinding(opt options.Option) (string, bool) {
+	bindings := []struct {
+		name string
+		used bool
+	}{
+		{"computed", len(opt.Computed) != 0},
+		{"constants", len(opt.Constants) != 0},
+		{"defaults", len(opt.Defaults) != 0},
+	}
+
+	for _, binding := range bindings {
+		if binding.used {
+			return binding.name, true
+		}
+	}
+
+	return "", false
+}
+
+// defaultParam returns name of model with lower first letter
+func defaultParam(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// mapMergeModels generates merge convertor of sources into to model.
+// Nested models of fields of every source are mapped by tag of the source if recursive flag is enabled
+func (m *modelMapper) mapMergeModels(
+	sources []mergeSource,
+	to models.Struct,
+	destination string,
+	funcs models.Functions,
+	toStructs map[string]models.Struct,
+) (models.Functions, error) {
+	// sources are transformed again by the next attempt of cyclic models
+	sources = slices.Clone(sources)
+	generatorSources := make([]generator.Source, 0, len(sources))
+	for i, source := range sources {
+		from, err := TransformAndFilterFields(m.lg, m.session, source.Tag, source.Model, nil)
+		if err != nil {
+			return nil, fmt.Errorf("transform and filter fields error: %w", err)
+		}
+
+		if from.Fields.Len() == 0 {
+			return nil, fmt.Errorf(
+				"%w: source model %s does not contain tag %s",
+				ErrNotFoundTag,
+				from.Type.Name,
+				source.Tag,
+			)
+		}
+
+		setPackageAliasToStruct(&from, m.aliases)
+		sources[i].Model = from
+		generatorSources = append(generatorSources, sources[i].Source)
+	}
+
+	to, err := TransformAndFilterFields(m.lg, m.session, m.toTag, to, nil)
+	if err != nil {
+		return nil, fmt.Errorf("transform and filter fields error: %w", err)
+	}
+
+	if to.Fields.Len() == 0 {
+		return nil, fmt.Errorf("%w: to model %s does not contain tag %s", ErrNotFoundTag, to.Type.Name, m.toTag)
+	}
+
+	if err = checkTagBindings(to, ErrMergeOption, "from-models"); err != nil {
+		return nil, err
+	}
+
+	setPackageAliasToStruct(&to, m.aliases)
+
+	pkg, err := m.destinationPackage(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		funcs = setPackageAliasToFunctions(funcs, m.aliases)
+		gcf, err := generator.GenerateMergeConvertor(generatorSources, to, m.toTag, pkg, funcs)
+		if err == nil {
+			m.convertors = append(m.convertors, pendingConvertor{
+				destination: destination,
+				pkg:         pkg,
+				gcf:         gcf,
+			})
+
+			return funcs, nil
+		}
+
+		var fieldsErr *generator.FieldsError
+		if !errors.As(err, &fieldsErr) {
+			return nil, fmt.Errorf("generate merge convertor error: %w", err)
+		}
+
+		resolved := false
+		mapped := make(map[models.Type]struct{})
+		for _, source := range sources {
+			if _, ok := mapped[source.Model.Type]; ok {
+				continue
+			}
+			mapped[source.Model.Type] = struct{}{}
+
+			var sourceResolved bool
+			m.fromTag = source.Tag
+			funcs, sourceResolved, err = m.mapNestedModels(
+				sourceFieldsError(fieldsErr, source.Model.Type),
+				source.Model,
+				to,
+				destination,
+				funcs,
+				source.structs,
+				toStructs,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved = resolved || sourceResolved
+		}
+
+		if resolved {
+			continue
+		}
+
+		m.reportFields(fieldsErr)
+		return nil, errFieldsReported
+	}
+}
+
+// sourceFieldsError returns errors of fields of from model
+func sourceFieldsError(fieldsErr *generator.FieldsError, from models.Type) *generator.FieldsError {
+	res := &generator.FieldsError{}
+	for _, fieldErr := range fieldsErr.Errors {
+		if fieldErr.From == from {
+			res.Errors = append(res.Errors, fieldErr)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,162 @@
+package mapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/underbek/datamapper/generator"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+)
+
+// StubsFileName is a file of conversion functions stubs in scaffold package
+const StubsFileName = "datamapper_stubs.go"
+
+const stubsFilePerm = 0600
+
+// scaffoldMissing writes stubs of missing conversion functions into opts.ScaffoldMissing package
+// until all functions are found and returns options which use the package as conversion functions source
+func scaffoldMissing(lg logger.Logger, opts options.Options) (options.Options, error) {
+	dir := opts.ScaffoldMissing
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return opts, fmt.Errorf("create scaffold dir %s error: %w", dir, err)
+	}
+
+	// package with other functions is used from the start, empty package is used after writing of stubs
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.go")); len(files) != 0 {
+		opts.ConversionFunctions = withFunctionsSource(opts.ConversionFunctions, dir)
+	}
+
+	scaffolded := make(map[models.ConversionFunctionKey]struct{})
+	for {
+		session := parser.NewSession(context.Background())
+		_, err := generateSources(lg, session, opts, true)
+		if err == nil {
+			return opts, nil
+		}
+
+		// functions which are still not found after scaffolding cannot be fixed by stubs
+		keys := missingFunctions(err, scaffolded)
+		if len(keys) == 0 {
+			return opts, err
+		}
+
+		pkg, pkgErr := session.ParseDestinationPackage(lg, dir)
+		if pkgErr != nil {
+			return opts, fmt.Errorf("parse scaffold package %s error: %w", dir, pkgErr)
+		}
+
+		aliases := stubsPackageAliases(pkg, keys)
+		cfs := make([]models.ConversionFunction, 0, len(keys))
+		for _, key := range keys {
+			setTypePackageAlias(&key.FromType, aliases)
+			setTypePackageAlias(&key.ToType, aliases)
+			cfs = append(cfs, generator.StubFunction(key.FromType, key.ToType, pkg))
+		}
+
+		dest := filepath.Join(dir, StubsFileName)
+		current, err := readDestination(dest)
+		if err != nil {
+			return opts, err
+		}
+
+		content, err := generator.GenerateStubsSource(pkg, current, cfs)
+		if err != nil {
+			return opts, fmt.Errorf("generate stubs source error: %w", err)
+		}
+
+		if err = os.WriteFile(dest, content, stubsFilePerm); err != nil {
+			return opts, fmt.Errorf("write stubs source error: %w", err)
+		}
+
+		opts.ConversionFunctions = withFunctionsSource(opts.ConversionFunctions, dir)
+		lg.Infof("scaffolded %d conversion functions: \"%s\"", len(cfs), dest)
+	}
+}
+
+// missingFunctions returns types of not found conversion functions of fields errors in order of errors.
+// Types are added to scaffolded, already scaffolded types are skipped
+func missingFunctions(err error, scaffolded map[models.ConversionFunctionKey]struct{}) []models.ConversionFunctionKey {
+	var fieldsErr *generator.FieldsError
+	if !errors.As(err, &fieldsErr) {
+		return nil
+	}
+
+	var res []models.ConversionFunctionKey
+	for _, fieldErr := range fieldsErr.Errors {
+		var findErr *generator.FindFieldsPairError
+		if !errors.As(fieldErr, &findErr) {
+			continue
+		}
+
+		key := models.ConversionFunctionKey{FromType: findErr.From, ToType: findErr.To}
+		setTypePackageAlias(&key.FromType, nil)
+		setTypePackageAlias(&key.ToType, nil)
+
+		if _, ok := scaffolded[key]; ok {
+			continue
+		}
+
+		scaffolded[key] = struct{}{}
+		res = append(res, key)
+	}
+
+	return res
+}
+
+// stubsPackageAliases returns aliases of packages of types which names conflict with other imported packages
+func stubsPackageAliases(pkg models.Package, keys []models.ConversionFunctionKey) map[string]string {
+	packages := make(models.Packages)
+	for _, key := range keys {
+		generator.TypePackages(key.FromType, packages)
+		generator.TypePackages(key.ToType, packages)
+	}
+
+	paths := make([]string, 0, len(packages))
+	names := make(map[string]string, len(packages))
+	for p := range packages {
+		if p.Path == "" || p.Path == pkg.Path {
+			continue
+		}
+
+		paths = append(paths, p.Path)
+		names[p.Path] = p.Name
+	}
+
+	sort.Strings(paths)
+
+	aliases := make(map[string]string)
+	used := map[string]string{pkg.Name: pkg.Path}
+	for _, path := range paths {
+		if usedPath, ok := used[names[path]]; ok && usedPath != path {
+			aliases[path] = generatePackageAlias(path)
+			continue
+		}
+
+		used[names[path]] = path
+	}
+
+	return aliases
+}
+
+// withFunctionsSource adds source of conversion functions if it is not used yet
+func withFunctionsSource(cfs []options.ConversionFunction, source string) []options.ConversionFunction {
+	abs, err := filepath.Abs(source)
+	for _, cf := range cfs {
+		cfAbs, cfErr := filepath.Abs(cf.Source)
+		if cf.Source == source || err == nil && cfErr == nil && cfAbs == abs {
+			return cfs
+		}
+	}
+
+	res := make([]options.ConversionFunction, 0, len(cfs)+1)
+	res = append(res, cfs...)
+
+	return append(res, options.ConversionFunction{Source: source})
+}
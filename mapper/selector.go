@@ -0,0 +1,184 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	namePlaceholder      = "{Name}"
+	lowerNamePlaceholder = "{name}"
+)
+
+var ErrIncorrectSelector = errors.New("incorrect selector error")
+
+func expandSelectors(lg logger.Logger, session *parser.Session, selectors []options.Selector,
+) ([]options.Option, error) {
+	var res []options.Option
+	for _, selector := range selectors {
+		opts, err := expandSelector(lg, session, selector)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, opts...)
+	}
+
+	return res, nil
+}
+
+func expandSelector(lg logger.Logger, session *parser.Session, selector options.Selector) ([]options.Option, error) {
+	fromPattern, isFromPointer := parseModelName(namePattern(selector.From.Name))
+	toPattern, isToPointer := parseModelName(namePattern(selector.To.Name))
+
+	if !strings.Contains(fromPattern, namePlaceholder) || !strings.Contains(toPattern, namePlaceholder) {
+		return nil, fmt.Errorf(
+			"%w: model names %s and %s must contain %s",
+			ErrIncorrectSelector,
+			selector.From.Name,
+			selector.To.Name,
+			namePlaceholder,
+		)
+	}
+
+	fromStructs, err := session.ParseModelsByPackage(lg, selector.From.Source)
+	if err != nil {
+		return nil, fmt.Errorf("parse models error: %w", err)
+	}
+
+	toStructs, err := session.ParseModelsByPackage(lg, selector.To.Source)
+	if err != nil {
+		return nil, fmt.Errorf("parse models error: %w", err)
+	}
+
+	fromNames := maps.Keys(fromStructs)
+	slices.Sort(fromNames)
+
+	var res []options.Option
+	for _, fromName := range fromNames {
+		name, ok := matchNamePattern(fromPattern, fromName)
+		if !ok {
+			continue
+		}
+
+		selected, err := isSelectedModel(fromName, selector.Include, selector.Exclude)
+		if err != nil {
+			return nil, err
+		}
+
+		if !selected {
+			continue
+		}
+
+		toName := fillNamePattern(toPattern, name)
+		if _, ok := toStructs[toName]; !ok {
+			lg.Infof("skip model %s: not found model %s in %s", fromName, toName, selector.To.Source)
+			continue
+		}
+
+		from := selector.From
+		from.Name = formatModelName(fromName, isFromPointer)
+
+		to := selector.To
+		to.Name = formatModelName(toName, isToPointer)
+
+		res = append(res, options.Option{
+			From:         from,
+			To:           to,
+			Destination:  fillNamePattern(selector.Destination, name),
+			Inverse:      selector.Inverse,
+			WithSlice:    selector.WithSlice,
+			Recursive:    selector.Recursive,
+			WithPointers: selector.WithPointers,
+
+			RecursivePackages: selector.RecursivePackages,
+			RoundTripTests:    selector.RoundTripTests,
+			Diff:              selector.Diff,
+			Apply:             selector.Apply,
+			InPlace:           selector.InPlace,
+		})
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf(
+			"%w: selector does not match any model from %s to %s",
+			ErrNotFoundStruct,
+			selector.From.Source,
+			selector.To.Source,
+		)
+	}
+
+	return res, nil
+}
+
+func namePattern(name string) string {
+	if name == "" || name == "*" {
+		return name + namePlaceholder
+	}
+
+	return name
+}
+
+func formatModelName(name string, isPointer bool) string {
+	if isPointer {
+		return "*" + name
+	}
+
+	return name
+}
+
+func matchNamePattern(pattern, name string) (string, bool) {
+	prefix, suffix, _ := strings.Cut(pattern, namePlaceholder)
+	if len(name) <= len(prefix)+len(suffix) {
+		return "", false
+	}
+
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return "", false
+	}
+
+	return name[len(prefix) : len(name)-len(suffix)], true
+}
+
+func fillNamePattern(pattern, name string) string {
+	res := strings.ReplaceAll(pattern, namePlaceholder, name)
+	return strings.ReplaceAll(res, lowerNamePlaceholder, strings.ToLower(name))
+}
+
+func isSelectedModel(name string, include, exclude []string) (bool, error) {
+	for _, pattern := range exclude {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("%w: exclude pattern %s: %s", ErrIncorrectSelector, pattern, err)
+		}
+
+		if ok {
+			return false, nil
+		}
+	}
+
+	if len(include) == 0 {
+		return true, nil
+	}
+
+	for _, pattern := range include {
+		ok, err := path.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("%w: include pattern %s: %s", ErrIncorrectSelector, pattern, err)
+		}
+
+		if ok {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,421 @@
+package mapper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/underbek/datamapper/generator"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/utils"
+	"golang.org/x/exp/maps"
+)
+
+var (
+	ErrDuplicateConvertor = errors.New("duplicate convertor error")
+	ErrStaleConvertors    = errors.New("stale convertors error")
+)
+
+type convertorSource struct {
+	pkg        models.Package
+	packages   models.Packages
+	names      []string
+	convertors []string
+	// test source contains round-trip tests instead of convertors
+	test bool
+}
+
+// Source is a generated source of destination file
+type Source struct {
+	Destination string
+	Package     models.Package
+	// Convertors are names of generated convertors in order of generation
+	Convertors []string
+	Content    []byte
+}
+
+// convertorSources collects generated convertors by destination files in order of generation
+type convertorSources struct {
+	layout       string
+	destinations []string
+	sources      map[string]*convertorSource
+	packageFiles map[string]string
+	bodies       map[string]string
+}
+
+func newConvertorSources(layout string) *convertorSources {
+	return &convertorSources{
+		layout:       layout,
+		sources:      make(map[string]*convertorSource),
+		packageFiles: make(map[string]string),
+		bodies:       make(map[string]string),
+	}
+}
+
+func (s *convertorSources) destination(dest string) string {
+	if s.layout != options.LayoutPackage || dest == options.StdoutDestination {
+		return dest
+	}
+
+	dir, err := filepath.Abs(utils.ClearFileName(dest))
+	if err != nil {
+		return dest
+	}
+
+	if file, ok := s.packageFiles[dir]; ok {
+		return file
+	}
+
+	s.packageFiles[dir] = dest
+
+	return dest
+}
+
+// add adds convertor or test into source of destination, tests are added into test file of destination
+func (s *convertorSources) add(dest string, pkg models.Package, gcf models.GeneratedConversionFunction, test bool,
+) error {
+	// convertors of one package must have unique names
+	key := pkg.Path + "." + gcf.Function.Name
+	if body, ok := s.bodies[key]; ok {
+		if body == gcf.Body {
+			return nil
+		}
+
+		return fmt.Errorf("%w: %s in package %s", ErrDuplicateConvertor, gcf.Function.Name, pkg.Path)
+	}
+
+	s.bodies[key] = gcf.Body
+
+	dest = s.destination(dest)
+	if test {
+		dest = testDestination(dest)
+	}
+
+	source, ok := s.sources[dest]
+	if !ok {
+		source = &convertorSource{
+			pkg:      pkg,
+			packages: make(models.Packages),
+			test:     test,
+		}
+		s.sources[dest] = source
+		s.destinations = append(s.destinations, dest)
+	}
+
+	source.names = append(source.names, gcf.Function.Name)
+	source.convertors = append(source.convertors, gcf.Body)
+	maps.Copy(source.packages, gcf.Packages)
+
+	return nil
+}
+
+func (s *convertorSources) write(lg logger.Logger, out io.Writer) error {
+	for _, dest := range s.destinations {
+		if dest == options.StdoutDestination {
+			content, err := s.render(dest)
+			if err != nil {
+				return err
+			}
+
+			_, err = out.Write(content)
+			if err != nil {
+				return fmt.Errorf("write convertor source to stdout error: %w", err)
+			}
+
+			continue
+		}
+
+		if err := s.create(dest, nil); err != nil {
+			return err
+		}
+
+		lg.Infof("generated convertor source: \"%s\"", dest)
+	}
+
+	return nil
+}
+
+// update writes only changed destination files and returns their absolute paths
+func (s *convertorSources) update(lg logger.Logger, out io.Writer) ([]string, error) {
+	var res []string
+	for _, dest := range s.destinations {
+		content, err := s.render(dest)
+		if err != nil {
+			return res, err
+		}
+
+		if dest == options.StdoutDestination {
+			if _, err = out.Write(content); err != nil {
+				return res, fmt.Errorf("write convertor source to stdout error: %w", err)
+			}
+
+			continue
+		}
+
+		current, err := readDestination(dest)
+		if err != nil {
+			return res, err
+		}
+
+		if bytes.Equal(current, content) {
+			continue
+		}
+
+		abs, err := filepath.Abs(dest)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, abs)
+		if err = s.create(dest, content); err != nil {
+			return res, err
+		}
+
+		lg.Infof("generated convertor source: \"%s\"", dest)
+	}
+
+	return res, nil
+}
+
+// files returns written destination files
+func (s *convertorSources) files() []string {
+	res := make([]string, 0, len(s.destinations))
+	for _, dest := range s.destinations {
+		if dest != options.StdoutDestination {
+			res = append(res, dest)
+		}
+	}
+
+	return res
+}
+
+func (s *convertorSources) render(dest string) ([]byte, error) {
+	source := s.sources[dest]
+	if source.test {
+		content, err := generator.GenerateTestSource(source.pkg, source.packages, source.convertors)
+		if err != nil {
+			return nil, fmt.Errorf("generate test source error: %w", err)
+		}
+
+		return content, nil
+	}
+
+	content, err := generator.GenerateConvertorSource(source.pkg, source.packages, source.convertors)
+	if err != nil {
+		return nil, fmt.Errorf("generate convertor source error: %w", err)
+	}
+
+	return content, nil
+}
+
+// create writes rendered content of destination, content is rendered if it is nil
+func (s *convertorSources) create(dest string, content []byte) error {
+	if content == nil {
+		var err error
+		if content, err = s.render(dest); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.Create(dest) //nolint:gosec
+	if err != nil {
+		return fmt.Errorf("create convertor source error: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	if _, err = file.Write(content); err != nil {
+		return fmt.Errorf("create convertor source error: %w", err)
+	}
+
+	return nil
+}
+
+// testDestination returns test file of destination
+func testDestination(dest string) string {
+	return strings.TrimSuffix(dest, ".go") + "_test.go"
+}
+
+func (s *convertorSources) list() ([]Source, error) {
+	res := make([]Source, 0, len(s.destinations))
+	for _, dest := range s.destinations {
+		content, err := s.render(dest)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, Source{
+			Destination: dest,
+			Package:     s.sources[dest].pkg,
+			Convertors:  s.sources[dest].names,
+			Content:     content,
+		})
+	}
+
+	return res, nil
+}
+
+// check compares generated sources with destination files and prints diffs of stale files
+// and generated files of destination directories without options.
+// Generated files matched by shared patterns belong to other configs and are not reported
+func (s *convertorSources) check(lg logger.Logger, out io.Writer, shared []string) error {
+	stale := 0
+	for _, dest := range s.destinations {
+		if dest == options.StdoutDestination {
+			continue
+		}
+
+		content, err := s.render(dest)
+		if err != nil {
+			return err
+		}
+
+		current, err := readDestination(dest)
+		if err != nil {
+			return err
+		}
+
+		diff := unifiedDiff(dest, dest, current, content)
+		if diff == "" {
+			lg.Infof("convertor source is up to date: \"%s\"", dest)
+			continue
+		}
+
+		stale++
+		_, _ = fmt.Fprint(out, diff)
+	}
+
+	orphans, err := s.orphans(shared)
+	if err != nil {
+		return err
+	}
+
+	for _, orphan := range orphans {
+		stale++
+		_, _ = fmt.Fprintf(out, "generated file %s has no option\n", orphan)
+	}
+
+	if stale != 0 {
+		return fmt.Errorf("%w: %d files are not up to date", ErrStaleConvertors, stale)
+	}
+
+	return nil
+}
+
+// dryRun prints list of destination files with their states and diffs of changed files
+func (s *convertorSources) dryRun(out io.Writer) error {
+	diffs := make([]string, 0, len(s.destinations))
+
+	_, _ = fmt.Fprintf(out, "datamapper dry run: %d files\n", len(s.destinations))
+	for _, dest := range s.destinations {
+		content, err := s.render(dest)
+		if err != nil {
+			return err
+		}
+
+		var current []byte
+		if dest != options.StdoutDestination {
+			current, err = readDestination(dest)
+			if err != nil {
+				return err
+			}
+		}
+
+		state := "unchanged"
+		switch {
+		case current == nil:
+			state = "new"
+		case !bytes.Equal(current, content):
+			state = "changed"
+		}
+
+		_, _ = fmt.Fprintf(out, "  %-9s %s\n", state, dest)
+
+		diffs = append(diffs, unifiedDiff(dest, dest, current, content))
+	}
+
+	for _, diff := range diffs {
+		_, _ = fmt.Fprint(out, diff)
+	}
+
+	return nil
+}
+
+// orphans returns generated files from destination directories which are not generated now
+// and are not matched by shared patterns
+func (s *convertorSources) orphans(shared []string) ([]string, error) {
+	generated := make(map[string]struct{}, len(s.destinations))
+	dirs := make(map[string]struct{})
+	for _, dest := range s.destinations {
+		if dest == options.StdoutDestination {
+			continue
+		}
+
+		abs, err := filepath.Abs(dest)
+		if err != nil {
+			return nil, err
+		}
+
+		generated[abs] = struct{}{}
+		dirs[filepath.Dir(abs)] = struct{}{}
+	}
+
+	for _, pattern := range shared {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("shared destinations pattern %s error: %w", pattern, err)
+		}
+
+		for _, file := range files {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				return nil, err
+			}
+
+			generated[abs] = struct{}{}
+		}
+	}
+
+	var res []string
+	for dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			if _, ok := generated[file]; ok {
+				continue
+			}
+
+			content, err := os.ReadFile(file) //nolint:gosec
+			if err != nil {
+				return nil, err
+			}
+
+			if bytes.HasPrefix(content, []byte(generator.GeneratedHeader)) {
+				res = append(res, file)
+			}
+		}
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+func readDestination(dest string) ([]byte, error) {
+	content, err := os.ReadFile(dest) //nolint:gosec
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	return content, err
+}
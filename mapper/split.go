@@ -0,0 +1,227 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/underbek/datamapper/generator"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	ErrSplitOption       = errors.New("split option error")
+	ErrNotConsumedFields = errors.New("not consumed fields error")
+)
+
+// splitTarget is a to model of split convertor with models of its package
+type splitTarget struct {
+	generator.Target
+	structs map[string]models.Struct
+}
+
+// mapSplitOption maps from model of option into several to models by split convertor
+func (g generation) mapSplitOption(
+	lg logger.Logger,
+	session *parser.Session,
+	opt options.Option,
+	funcs models.Functions,
+	readOnly bool,
+) (models.Functions, optionResult) {
+	if err := checkSplitOption(opt); err != nil {
+		return funcs, optionResult{err: err}
+	}
+
+	from, fromStructs, err := parseOptionModel(lg, session, opt.From, "source")
+	if err != nil {
+		return funcs, optionResult{err: err}
+	}
+
+	aliases := map[string]string{from.Type.Package.Path: opt.From.Alias}
+	targets := make([]splitTarget, 0, len(opt.ToModels))
+	for _, model := range opt.ToModels {
+		to, structs, err := parseOptionModel(lg, session, model, "to")
+		if err != nil {
+			return funcs, optionResult{err: err}
+		}
+
+		aliases[to.Type.Package.Path] = model.Alias
+		targets = append(targets, splitTarget{
+			Target:  generator.Target{Model: to, Tag: model.Tag},
+			structs: structs,
+		})
+	}
+
+	recursivePackages, err := parseRecursivePackages(lg, session, opt.RecursivePackages, aliases)
+	if err != nil {
+		return funcs, optionResult{err: fmt.Errorf("parse recursive packages error: %w", err)}
+	}
+
+	maps.Copy(aliases, g.cfAliases)
+
+	m := g.newModelMapper(lg, session, opt, aliases, recursivePackages, readOnly)
+	res, err := m.mapRoot(func() (models.Functions, error) {
+		return m.mapSplitModels(from, fromStructs, targets, opt.Destination, opt.Strict, copyFunctions(funcs))
+	})
+	if err != nil {
+		return funcs, optionResult{err: err}
+	}
+
+	return res, optionResult{convertors: m.convertors}
+}
+
+// checkSplitOption checks that option with to models does not use flags of single to model
+func checkSplitOption(opt options.Option) error {
+	if opt.To.Name != "" {
+		return fmt.Errorf("%w: to and to-models cannot be set together", ErrSplitOption)
+	}
+
+	if flag, ok := pairFlag(opt); ok {
+		return fmt.Errorf("%w: %s is not supported with to-models", ErrSplitOption, flag)
+	}
+
+	if binding, ok := fieldsBinding(opt); ok {
+		return fmt.Errorf("%w: %s is not supported with to-models", ErrSplitOption, binding)
+	}
+
+	return nil
+}
+
+// mapSplitModels generates split convertor of from model into targets.
+// Nested models of fields of every target are mapped by tag of the target if recursive flag is enabled
+func (m *modelMapper) mapSplitModels(
+	from models.Struct,
+	fromStructs map[string]models.Struct,
+	targets []splitTarget,
+	destination string,
+	strict bool,
+	funcs models.Functions,
+) (models.Functions, error) {
+	from, err := TransformAndFilterFields(m.lg, m.session, m.fromTag, from, nil)
+	if err != nil {
+		return nil, fmt.Errorf("transform and filter fields error: %w", err)
+	}
+
+	if from.Fields.Len() == 0 {
+		return nil, fmt.Errorf("%w: source model %s does not contain tag %s", ErrNotFoundTag, from.Type.Name, m.fromTag)
+	}
+
+	setPackageAliasToStruct(&from, m.aliases)
+
+	// targets are transformed again by the next attempt of cyclic models
+	targets = slices.Clone(targets)
+	generatorTargets := make([]generator.Target, 0, len(targets))
+	for i, target := range targets {
+		to, err := TransformAndFilterFields(m.lg, m.session, target.Tag, target.Model, nil)
+		if err != nil {
+			return nil, fmt.Errorf("transform and filter fields error: %w", err)
+		}
+
+		if to.Fields.Len() == 0 {
+			return nil, fmt.Errorf("%w: to model %s does not contain tag %s", ErrNotFoundTag, to.Type.Name, target.Tag)
+		}
+
+		if err = checkTagBindings(to, ErrSplitOption, "to-models"); err != nil {
+			return nil, err
+		}
+
+		setPackageAliasToStruct(&to, m.aliases)
+		targets[i].Model = to
+		generatorTargets = append(generatorTargets, targets[i].Target)
+	}
+
+	if strict {
+		if err = checkConsumedFields(from, generatorTargets); err != nil {
+			return nil, err
+		}
+	}
+
+	pkg, err := m.destinationPackage(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		funcs = setPackageAliasToFunctions(funcs, m.aliases)
+		gcf, err := generator.GenerateSplitConvertor(from, m.fromTag, generatorTargets, pkg, funcs)
+		if err == nil {
+			m.convertors = append(m.convertors, pendingConvertor{
+				destination: destination,
+				pkg:         pkg,
+				gcf:         gcf,
+			})
+
+			return funcs, nil
+		}
+
+		var fieldsErr *generator.FieldsError
+		if !errors.As(err, &fieldsErr) {
+			return nil, fmt.Errorf("generate split convertor error: %w", err)
+		}
+
+		resolved := false
+		mapped := make(map[models.Type]struct{})
+		for _, target := range targets {
+			if _, ok := mapped[target.Model.Type]; ok {
+				continue
+			}
+			mapped[target.Model.Type] = struct{}{}
+
+			var targetResolved bool
+			m.toTag = target.Tag
+			funcs, targetResolved, err = m.mapNestedModels(
+				targetFieldsError(fieldsErr, target.Model.Type),
+				from,
+				target.Model,
+				destination,
+				funcs,
+				fromStructs,
+				target.structs,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			resolved = resolved || targetResolved
+		}
+
+		if resolved {
+			continue
+		}
+
+		m.reportFields(fieldsErr)
+		return nil, errFieldsReported
+	}
+}
+
+// checkConsumedFields checks that every field of from model is converted into some of to models
+func checkConsumedFields(from models.Struct, targets []generator.Target) error {
+	fields := generator.NotConsumedFields(from, targets)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, fmt.Sprintf("%s.%s (tag %s)", from.Type.Name, field.Name, field.Tags[0].Value))
+	}
+
+	return fmt.Errorf("%w: %s", ErrNotConsumedFields, strings.Join(names, ", "))
+}
+
+// targetFieldsError returns errors of fields of to model
+func targetFieldsError(fieldsErr *generator.FieldsError, to models.Type) *generator.FieldsError {
+	res := &generator.FieldsError{}
+	for _, fieldErr := range fieldsErr.Errors {
+		if fieldErr.To == to {
+			res.Errors = append(res.Errors, fieldErr)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,124 @@
+package mapper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/underbek/datamapper/models"
+)
+
+// options of mapping tag which set Go expressions into field like `map:"version,const=2"`
+const (
+	constOption   = "const"
+	defaultOption = "default"
+)
+
+var ErrFieldValue = errors.New("field value error")
+
+// bindValues sets constants and defaults into bound fields of model, values are checked by types of fields.
+// Bindings of option override options of tags of fields of the root to model.
+// Defaults must be used by conversion of nil pointer fields of from model
+func (m *modelMapper) bindValues(model *models.Struct, from models.Struct) error {
+	fromFields := make(map[string]models.Field)
+	from.Fields.Range(func(field models.Field) {
+		fromFields[field.Tags[0].Value] = field
+	})
+
+	return model.Fields.Each(func(field *models.Field) error {
+		field.Constant, _ = m.fieldBinding(model.Type, *field, m.constants, constOption)
+		field.Default, _ = m.fieldBinding(model.Type, *field, m.defaults, defaultOption)
+
+		path := fullFieldNameForSkipComment(*field)
+		if field.Constant != "" && (field.Computed != nil || field.Default != "") {
+			return fmt.Errorf(
+				"%w: field %s.%s with constant cannot be computed or have default",
+				ErrFieldValue,
+				model.Type.Name,
+				path,
+			)
+		}
+
+		if field.Default != "" {
+			if reason := unusedDefault(*field, fromFields); reason != "" {
+				return fmt.Errorf(
+					"%w: default of field %s.%s is never used, %s",
+					ErrFieldValue,
+					model.Type.Name,
+					path,
+					reason,
+				)
+			}
+		}
+
+		for _, value := range []string{field.Constant, field.Default} {
+			if value == "" {
+				continue
+			}
+
+			if err := m.session.CheckFieldValue(m.lg, model.Type, path, value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// unusedDefault returns reason why default of field is never used or empty string.
+// Default is used only if from field is a nil pointer converted into value
+func unusedDefault(field models.Field, fromFields map[string]models.Field) string {
+	if field.Computed != nil {
+		return "field is computed"
+	}
+
+	from, ok := fromFields[field.Tags[0].Value]
+	if !ok {
+		return "from model has not field " + field.Tags[0].Value
+	}
+
+	if !from.Type.Pointer || field.Type.Pointer {
+		return "from field " + fullFieldNameForSkipComment(from) + " is not a pointer converted into value"
+	}
+
+	for head := from.Head; head != nil; head = head.Head {
+		if head.Type.Pointer {
+			return "nil embedded struct " + head.Name + " of from field is converted into error"
+		}
+	}
+
+	return ""
+}
+
+// fieldBinding returns value bound to field by bindings of option or by option of tag
+func (m *modelMapper) fieldBinding(model models.Type, field models.Field, bindings map[string]string, option string,
+) (string, bool) {
+	if sameModel(model, m.rootTo) {
+		if value, ok := bindings[fullFieldNameForSkipComment(field)]; ok {
+			return value, true
+		}
+	}
+
+	value, ok := field.Tags[0].Options[option]
+	return value, ok && value != ""
+}
+
+// checkTagBindings checks that fields of model have not compute, const and default options of tag
+// which are not supported by merge and split convertors
+func checkTagBindings(model models.Struct, target error, kind string) error {
+	return model.Fields.Each(func(field *models.Field) error {
+		for _, option := range []string{computeOption, constOption, defaultOption} {
+			if _, ok := field.Tags[0].Options[option]; ok {
+				return fmt.Errorf(
+					"%w: %s option of field %s.%s is not supported with %s",
+					target,
+					option,
+					model.Type.Name,
+					fullFieldNameForSkipComment(*field),
+					kind,
+				)
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,295 @@
+package mapper
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/options"
+	"github.com/underbek/datamapper/parser"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+const watchInterval = 500 * time.Millisecond
+
+// WatchModels generates convertors and regenerates them on changes of used packages until interrupt.
+// Only groups of options which use changed packages are mapped again. Errors are logged without exit.
+func WatchModels(lg logger.Logger, opts options.Options, out io.Writer) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return newWatcher(lg, opts, out).run(ctx)
+}
+
+type fileStamp struct {
+	size    int64
+	modTime int64
+}
+
+// dirStamps are stamps of go files by package directories
+type dirStamps map[string]map[string]fileStamp
+
+type watchGroup struct {
+	options []int
+	dirs    []string
+	failed  bool
+}
+
+type watcher struct {
+	lg       logger.Logger
+	opts     options.Options
+	out      io.Writer
+	interval time.Duration
+	// onRun is called after every generation with mapped options
+	onRun func(mapped []int, err error)
+
+	// session is one for the watcher lifetime, caches of changed packages are evicted
+	session *parser.Session
+	stamps  dirStamps
+	// written are directories of files written by the previous generation
+	written []string
+	global  []string
+	options []options.Option
+	groups  []watchGroup
+	results []optionResult
+}
+
+func newWatcher(lg logger.Logger, opts options.Options, out io.Writer) *watcher {
+	return &watcher{
+		lg:       lg,
+		opts:     opts,
+		out:      out,
+		interval: watchInterval,
+		onRun:    func([]int, error) {},
+		stamps:   make(dirStamps),
+	}
+}
+
+func (w *watcher) run(ctx context.Context) error {
+	w.session = newSession(ctx, openDiskCache(w.lg, w.opts))
+
+	var changed map[string]struct{}
+	for {
+		mapped, err := w.generate(ctx, changed)
+		if err != nil {
+			w.lg.Error(err)
+		}
+
+		w.onRun(mapped, err)
+
+		w.lg.Infof("watching %d packages", len(w.dirs()))
+
+		for changed = nil; len(changed) == 0; {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(w.interval):
+			}
+
+			changed = w.poll()
+		}
+
+		for dir := range changed {
+			w.lg.Infof("package changed: \"%s\"", dir)
+		}
+	}
+}
+
+// generate maps options which use changed directories and writes changed destination files.
+// All options are mapped if changed is nil
+func (w *watcher) generate(ctx context.Context, changed map[string]struct{}) ([]int, error) {
+	before := w.stamps
+	written := make(map[string]struct{})
+	defer func() {
+		w.stamps = w.snapshot(before, written)
+		w.written = w.written[:0]
+		for file := range written {
+			if dir, err := filepath.Abs(filepath.Dir(file)); err == nil {
+				w.written = append(w.written, dir)
+			}
+		}
+	}()
+
+	if changed != nil {
+		// only changed packages and packages which import them are parsed again
+		evicted := w.session.Evict(maps.Keys(changed)...)
+		changed = make(map[string]struct{}, len(evicted))
+		for _, dir := range evicted {
+			changed[dir] = struct{}{}
+		}
+	}
+
+	// written convertors can be used as conversion functions
+	w.session.Evict(w.written...)
+
+	session := w.session
+	session.Preload(w.lg, optionsSources(w.opts))
+
+	global := session.Track()
+	g, err := prepareGeneration(w.lg, global, w.opts)
+	w.global = global.Dirs()
+	if err != nil {
+		return nil, err
+	}
+
+	all := changed == nil || intersects(w.global, changed) || !reflect.DeepEqual(g.options, w.options)
+
+	groups := groupOptions(w.lg, session, g.options)
+	results := make([]optionResult, len(g.options))
+	trackers := make(map[int]*parser.Session)
+	newGroups := make([]watchGroup, 0, len(groups))
+
+	var mapped []int
+	var toMap [][]int
+	for _, group := range groups {
+		if old, ok := w.group(group); ok && !all && !old.failed && !intersects(old.dirs, changed) {
+			for _, index := range group {
+				results[index] = w.results[index]
+			}
+
+			newGroups = append(newGroups, old)
+			continue
+		}
+
+		trackers[group[0]] = session.Track()
+		toMap = append(toMap, group)
+		mapped = append(mapped, group...)
+	}
+
+	runGroups(ctx, parallelism(w.opts.Parallel), toMap, func(group []int) {
+		g.mapGroup(w.lg, trackers[group[0]], group, false, results)
+	})
+
+	for _, group := range toMap {
+		failed := false
+		for _, index := range group {
+			failed = failed || results[index].err != nil
+		}
+
+		newGroups = append(newGroups, watchGroup{
+			options: group,
+			dirs:    trackers[group[0]].Dirs(),
+			failed:  failed,
+		})
+	}
+
+	slices.Sort(mapped)
+	w.options, w.groups, w.results = g.options, newGroups, results
+
+	sources, err := mergeResults(g.layout, results)
+	if err != nil {
+		return mapped, err
+	}
+
+	files, err := sources.update(w.lg, w.out)
+	for _, file := range files {
+		written[file] = struct{}{}
+	}
+
+	return mapped, err
+}
+
+func (w *watcher) group(options []int) (watchGroup, bool) {
+	for _, group := range w.groups {
+		if slices.Equal(group.options, options) {
+			return group, true
+		}
+	}
+
+	return watchGroup{}, false
+}
+
+// dirs returns all directories used by options
+func (w *watcher) dirs() []string {
+	res := slices.Clone(w.global)
+	for _, group := range w.groups {
+		res = append(res, group.dirs...)
+	}
+
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// poll returns changed directories and keeps their current stamps
+func (w *watcher) poll() map[string]struct{} {
+	changed := make(map[string]struct{})
+	current := make(dirStamps)
+	for _, dir := range w.dirs() {
+		current[dir] = stampDir(dir)
+		if !equalStamps(w.stamps[dir], current[dir]) {
+			changed[dir] = struct{}{}
+		}
+	}
+
+	w.stamps = current
+	return changed
+}
+
+// snapshot returns current stamps of used directories. Stamps of files which are changed during generation
+// and are not written by it are kept from before, so these changes are found by the next poll
+func (w *watcher) snapshot(before dirStamps, written map[string]struct{}) dirStamps {
+	res := make(dirStamps)
+	for _, dir := range w.dirs() {
+		res[dir] = stampDir(dir)
+		for file, stamp := range res[dir] {
+			if _, ok := written[file]; ok {
+				continue
+			}
+
+			if old, ok := before[dir][file]; ok && old != stamp {
+				res[dir][file] = old
+			}
+		}
+	}
+
+	return res
+}
+
+func stampDir(dir string) map[string]fileStamp {
+	res := make(map[string]fileStamp)
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return res
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+
+		res[file] = fileStamp{size: info.Size(), modTime: info.ModTime().UnixNano()}
+	}
+
+	return res
+}
+
+func equalStamps(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for file, stamp := range a {
+		if other, ok := b[file]; !ok || other != stamp {
+			return false
+		}
+	}
+
+	return true
+}
+
+func intersects(dirs []string, set map[string]struct{}) bool {
+	for _, dir := range dirs {
+		if _, ok := set[dir]; ok {
+			return true
+		}
+	}
+
+	return false
+}
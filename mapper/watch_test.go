@@ -0,0 +1,123 @@
+package mapper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/options"
+)
+
+const watchPath = "../_test_data/generated/watch"
+
+type watchRun struct {
+	mapped []int
+	err    error
+}
+
+func writeModels(t *testing.T, pkg, fields string) {
+	dir := filepath.Join(watchPath, pkg)
+	require.NoError(t, os.MkdirAll(dir, 0700))
+
+	content := "package " + pkg + "\n\ntype User struct {\n\tID int64 `map:\"id\"`\n" + fields + "}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte(content), 0600))
+}
+
+func Test_WatchModels(t *testing.T) {
+	defer clearDestination(t, watchPath)
+
+	writeModels(t, "domain", "")
+	writeModels(t, "dto", "")
+
+	userDest := watchPath + "/dto/user_convertor.go"
+	opts := options.Options{
+		Layout: options.LayoutOption,
+		Options: []options.Option{
+			{
+				Destination: userDest,
+				From:        options.Model{Source: watchPath + "/domain", Name: "User", Tag: modelTag},
+				To:          options.Model{Source: watchPath + "/dto", Name: "User", Tag: toModelTag},
+			},
+			{
+				Destination: watchPath + "/basket/basket.go",
+				Recursive:   true,
+				From: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/domain",
+					Name:   "Basket",
+					Tag:    modelTag,
+				},
+				To: options.Model{
+					Source: "../_test_data/mapper/recursive_containers/dto",
+					Name:   "Basket",
+					Tag:    toModelTag,
+				},
+			},
+		},
+	}
+
+	runs := make(chan watchRun, 10)
+	w := newWatcher(logger.New(), opts, os.Stdout)
+	w.interval = 20 * time.Millisecond
+	w.onRun = func(mapped []int, err error) {
+		runs <- watchRun{mapped: mapped, err: err}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.run(ctx)
+	}()
+
+	next := func() watchRun {
+		select {
+		case run := <-runs:
+			return run
+		case <-time.After(time.Minute):
+			require.FailNow(t, "watcher did not regenerate convertors")
+			return watchRun{}
+		}
+	}
+
+	run := next()
+	require.NoError(t, run.err)
+	assert.Equal(t, []int{0, 1}, run.mapped)
+	assert.FileExists(t, watchPath+"/basket/basket.go")
+
+	// generated files do not trigger generation
+	select {
+	case run = <-runs:
+		assert.Failf(t, "unexpected generation", "mapped %v", run.mapped)
+	case <-time.After(10 * w.interval):
+	}
+
+	writeModels(t, "domain", "\tName string `map:\"name\"`\n")
+	writeModels(t, "dto", "\tName string `map:\"name\"`\n")
+	run = next()
+	for run.err != nil {
+		// domain change can be found before dto change
+		run = next()
+	}
+
+	assert.Equal(t, []int{0}, run.mapped)
+	content, err := os.ReadFile(userDest)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Name: from.Name")
+
+	writeModels(t, "dto", "\tName chan int `map:\"name\"`\n")
+	run = next()
+	assert.Error(t, run.err)
+	assert.Equal(t, []int{0}, run.mapped)
+
+	writeModels(t, "dto", "\tName  string `map:\"name\"`\n")
+	run = next()
+	require.NoError(t, run.err)
+	assert.Equal(t, []int{0}, run.mapped)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
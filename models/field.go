@@ -1,5 +1,10 @@
 package models
 
+import (
+	"bytes"
+	"encoding/gob"
+)
+
 type Field struct {
 	Name          string
 	Type          Type
@@ -7,6 +12,12 @@ type Field struct {
 	CurrentStruct *Struct
 	Head          *Field
 	Tags          []Tag
+	// Computed is a function of whole from model which result is assigned into the field
+	Computed *ConversionFunction
+	// Constant is a Go expression which is assigned into the field instead of from field
+	Constant string
+	// Default is a Go expression which is assigned into the field if from field is nil pointer
+	Default string
 }
 
 type Fields struct {
@@ -86,3 +97,17 @@ func (f Fields) Filter(fn FilterFunc) Fields {
 func (f *Fields) Add(field ...Field) {
 	f.fields = append(f.fields, field...)
 }
+
+func (f Fields) GobEncode() ([]byte, error) {
+	buf := bytes.Buffer{}
+	err := gob.NewEncoder(&buf).Encode(f.fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (f *Fields) GobDecode(data []byte) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(&f.fields)
+}
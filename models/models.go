@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/gob"
 	"fmt"
 )
 
@@ -57,9 +58,18 @@ type MapAdditional struct {
 	ValueType Type
 }
 
+func init() {
+	// types of additional info are registered to encode types by gob
+	gob.Register(ArrayAdditional{})
+	gob.Register(SliceAdditional{})
+	gob.Register(MapAdditional{})
+}
+
 type Tag struct {
 	Name  string
 	Value string
+	// Options are key=value options of tag after its value, it is nil without options
+	Options map[string]string
 }
 
 type Struct struct {
@@ -15,17 +15,48 @@ import (
 
 const defaultFilePerm = 0600
 
+// StdoutDestination writes generated source to stdout instead of a file
+const StdoutDestination = "-"
+
+// FunctionsCommand lists conversion functions instead of mapping models
+const FunctionsCommand = "functions"
+
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+)
+
+const (
+	// LayoutOption writes all convertors of an option into the option destination
+	LayoutOption = "option"
+	// LayoutModel writes convertors of recursive models into separate files near the option destination
+	LayoutModel = "model"
+	// LayoutPackage writes all convertors of a destination package into one file
+	LayoutPackage = "package"
+)
+
 //nolint:lll
 type Config struct {
 	ConfigPath string `short:"c" long:"config" description:"Yaml config path" required:"false"`
+	Check      bool   `long:"check" description:"Check that destination files are up to date without writing them"`
+	DryRun     bool   `long:"dry-run" description:"Print generated files and diffs without writing them"`
+	Parallel   int    `long:"parallel" description:"Number of independent options mapped concurrently (default: number of CPUs)"`
+	Verbose    bool   `long:"verbose" description:"Log timings of loading, parsing and generation"`
+	Explain    bool   `long:"explain" description:"Print how every destination field is converted without writing convertors"`
+	Format     string `long:"format" description:"Explain output format" choice:"table" choice:"json" default:"table"`
+	Watch      bool   `long:"watch" description:"Regenerate convertors on changes of used packages until interrupt"`
+	NoCache    bool   `long:"no-cache" description:"Do not use on-disk cache of parsed packages and always regenerate convertors"`
+	CacheDir   string `long:"cache-dir" description:"On-disk cache directory, cache is disabled if it is not set"`
+	Scaffold   string `long:"scaffold-missing" description:"Write stubs of missing conversion functions into the package directory and use them"`
 	Flags
 }
 
 //nolint:lll
 type Flags struct {
 	Version       bool     `short:"v" long:"version" description:"Current version"`
-	Destination   string   `short:"d" long:"destination" description:"Destination file path" required:"true"`
+	Destination   string   `short:"d" long:"destination" description:"Destination file path. Use - to write source to stdout" required:"true"`
 	UserCFSources []string `long:"cf" description:"User conversion functions sources/packages. Can add package alias like {package_path}:{alias)" required:"false"`
+	Computed      []string `long:"computed" description:"Computed functions sources/packages, functions are used only by computed fields. Can add package alias like {package_path}:{alias)" required:"false"`
 	FromName      string   `long:"from" description:"Model from name" required:"true"`
 	FromTag       string   `long:"from-tag" description:"Model from tag" default:"map" required:"false"`
 	FromSource    string   `long:"from-source" description:"From model source/package. Can add package alias like {package_path}:{alias)" default:"." required:"false"`
@@ -36,6 +67,19 @@ type Flags struct {
 	WithSlice     bool     `short:"s" long:"with-slice" description:"Create convertors with slice" required:"false"`
 	Recursive     bool     `short:"r" long:"recursive" description:"Parse recursive fields and create conversion if it not exists"`
 	WithPointers  bool     `short:"p" long:"with-pointers" description:"If field is pointer and recursive flag enabled then create convertors with pointers"`
+	RoundTrip     bool     `long:"round-trip-tests" description:"Create fuzz tests of round-trip conversions of inverse convertors"`
+	Diff          bool     `long:"diff" description:"Create Diff{From}{To} helpers which compare fields of mapped models"`
+	Apply         bool     `long:"apply" description:"Create Apply{From}To{To} functions which assign non-zero fields into existing models"`
+	InPlace       bool     `long:"in-place" description:"Create Convert{From}Into{To} convertors into existing models with benchmarks"`
+	Layout        string   `long:"layout" description:"Destination files layout: one file per option, per model or per package" choice:"option" choice:"model" choice:"package" default:"model"`
+}
+
+//nolint:lll
+type FunctionsConfig struct {
+	ConfigPath    string   `short:"c" long:"config" description:"Yaml config path with conversion functions" required:"false"`
+	UserCFSources []string `long:"cf" description:"User conversion functions sources/packages. Can add package alias like {package_path}:{alias)" required:"false"`
+	Type          string   `long:"type" description:"Show only functions which convert from or to the type"`
+	Format        string   `long:"format" description:"Output format" choice:"table" choice:"json" default:"table"`
 }
 
 type Model struct {
@@ -43,21 +87,132 @@ type Model struct {
 	Tag    string `yaml:"tag" default:"map"`
 	Source string `yaml:"source" default:"."`
 	Alias  string `yaml:"alias"`
+	// Param is a parameter name of from model of merge convertor
+	Param string `yaml:"param"`
+}
+
+type Package struct {
+	Source string `yaml:"source"`
+	Alias  string `yaml:"alias"`
+}
+
+type PackagePair struct {
+	From Package `yaml:"from"`
+	To   Package `yaml:"to"`
 }
 
 type Option struct {
-	From         Model  `yaml:"from"`
-	To           Model  `yaml:"to"`
-	Inverse      bool   `yaml:"inverse"`
-	Destination  string `yaml:"destination"`
-	WithSlice    bool   `yaml:"with-slice"`
-	Recursive    bool   `yaml:"recursive"`
-	WithPointers bool   `yaml:"with-pointers"`
+	From              Model         `yaml:"from"`
+	To                Model         `yaml:"to"`
+	Inverse           bool          `yaml:"inverse"`
+	Destination       string        `yaml:"destination"`
+	WithSlice         bool          `yaml:"with-slice"`
+	Recursive         bool          `yaml:"recursive"`
+	WithPointers      bool          `yaml:"with-pointers"`
+	RecursivePackages []PackagePair `yaml:"recursive-packages"`
+	RoundTripTests    bool          `yaml:"round-trip-tests"`
+	Diff              bool          `yaml:"diff"`
+	Apply             bool          `yaml:"apply"`
+	InPlace           bool          `yaml:"in-place"`
+	// FromModels are from models of merge convertor in order of precedence, From must be empty
+	FromModels []Model `yaml:"from-models"`
+	// ToModels are to models of split convertor in order of its results, To must be empty
+	ToModels []Model `yaml:"to-models"`
+	// Strict checks that every field of from model of split convertor is converted into some of to models
+	Strict bool `yaml:"strict"`
+	// Computed binds fields of to model by paths of Go names to computed functions by {name} or
+	// {package path}.{name}, bindings override compute options of tags
+	Computed map[string]string `yaml:"computed"`
+	// Constants are Go expressions assigned into fields of to model by paths of Go names instead of from fields,
+	// they override const options of tags
+	Constants map[string]string `yaml:"constants"`
+	// Defaults are Go expressions assigned into fields of to model by paths of Go names if from fields are
+	// nil pointers, they override default options of tags
+	Defaults map[string]string `yaml:"defaults"`
+}
+
+// Sources returns from models of option
+func (o Option) Sources() []Model {
+	if len(o.FromModels) != 0 {
+		return o.FromModels
+	}
+
+	return []Model{o.From}
+}
+
+// Targets returns to models of option
+func (o Option) Targets() []Model {
+	if len(o.ToModels) != 0 {
+		return o.ToModels
+	}
+
+	return []Model{o.To}
+}
+
+// Models returns from and to models of option
+func (o Option) Models() []Model {
+	res := make([]Model, 0, len(o.FromModels)+len(o.ToModels)+2)
+	res = append(res, o.Sources()...)
+	return append(res, o.Targets()...)
+}
+
+type Selector struct {
+	From         Model    `yaml:"from"`
+	To           Model    `yaml:"to"`
+	Include      []string `yaml:"include"`
+	Exclude      []string `yaml:"exclude"`
+	Destination  string   `yaml:"destination"`
+	Inverse      bool     `yaml:"inverse"`
+	WithSlice    bool     `yaml:"with-slice"`
+	Recursive    bool     `yaml:"recursive"`
+	WithPointers bool     `yaml:"with-pointers"`
+
+	RecursivePackages []PackagePair `yaml:"recursive-packages"`
+	RoundTripTests    bool          `yaml:"round-trip-tests"`
+	Diff              bool          `yaml:"diff"`
+	Apply             bool          `yaml:"apply"`
+	InPlace           bool          `yaml:"in-place"`
 }
 
 type Options struct {
 	ConversionFunctions []ConversionFunction `yaml:"conversion-functions"`
 	Options             []Option             `yaml:"options"`
+	Selectors           []Selector           `yaml:"selectors"`
+	Layout              string               `yaml:"layout"`
+	// ComputedFunctions are sources of functions of whole from models, they are used only by computed bindings
+	// and are not used as conversion functions
+	ComputedFunctions []ConversionFunction `yaml:"computed-functions"`
+	// SharedDestinations are glob patterns of files generated by other configs into destination packages,
+	// check doesn't report them as generated files without options
+	SharedDestinations []string `yaml:"shared-destinations"`
+	// LossyFunctions are conversion functions by {name} or {package path}.{name} which fields are not checked
+	// by round-trip tests
+	LossyFunctions []string `yaml:"lossy-functions"`
+	// CacheDir is a directory of on-disk cache of parsed packages, cache is disabled if it is empty
+	CacheDir string `yaml:"cache-dir"`
+	// Parallel is a number of options groups mapped concurrently, 0 is a number of CPUs
+	Parallel int `yaml:"parallel"`
+
+	// Check compares generated convertors with destination files instead of writing them
+	Check bool `yaml:"-"`
+	// DryRun prints generated files and diffs instead of writing them
+	DryRun bool `yaml:"-"`
+	// Verbose logs timings of generation
+	Verbose bool `yaml:"-"`
+	// NoCache disables on-disk cache
+	NoCache bool `yaml:"-"`
+	// Watch regenerates convertors on changes of used packages
+	Watch bool `yaml:"-"`
+	// Explain prints conversions of destination fields instead of writing convertors
+	Explain bool `yaml:"-"`
+	// Format is an explain and functions output format: table or json
+	Format string `yaml:"-"`
+	// ListFunctions prints resolved conversion functions instead of mapping models
+	ListFunctions bool `yaml:"-"`
+	// FunctionsType filters listed conversion functions by from or to type
+	FunctionsType string `yaml:"-"`
+	// ScaffoldMissing is a package directory for stubs of missing conversion functions
+	ScaffoldMissing string `yaml:"-"`
 }
 
 type ConversionFunction struct {
@@ -94,20 +249,16 @@ func parseConfig(path string) (Options, error) {
 }
 
 func parseFlags(params Flags) (Options, error) {
-	functions := make([]ConversionFunction, 0, len(params.UserCFSources))
-	for _, opt := range params.UserCFSources {
-		source, alias := parseSourceOption(opt)
-		functions = append(functions, ConversionFunction{
-			Source: source,
-			Alias:  alias,
-		})
-	}
+	functions := parseFunctionsSources(params.UserCFSources)
+	computed := parseFunctionsSources(params.Computed)
 
 	fromSource, fromAlias := parseSourceOption(params.FromSource)
 	toSource, toAlias := parseSourceOption(params.ToSource)
 
 	return Options{
 		ConversionFunctions: functions,
+		ComputedFunctions:   computed,
+		Layout:              params.Layout,
 		Options: []Option{
 			{
 				Destination: params.Destination,
@@ -123,16 +274,24 @@ func parseFlags(params Flags) (Options, error) {
 					Source: toSource,
 					Alias:  toAlias,
 				},
-				Inverse:      params.Inverse,
-				WithSlice:    params.WithSlice,
-				Recursive:    params.Recursive,
-				WithPointers: params.WithPointers,
+				Inverse:        params.Inverse,
+				WithSlice:      params.WithSlice,
+				Recursive:      params.Recursive,
+				WithPointers:   params.WithPointers,
+				RoundTripTests: params.RoundTrip,
+				Diff:           params.Diff,
+				Apply:          params.Apply,
+				InPlace:        params.InPlace,
 			},
 		},
 	}, nil
 }
 
 func ParseOptions() (Options, error) {
+	if len(os.Args) > 1 && os.Args[1] == FunctionsCommand {
+		return parseFunctionsCommand(os.Args[2:])
+	}
+
 	var config Config
 	_, err := flags.NewParser(&config, flags.HelpFlag|flags.PassDoubleDash).Parse()
 	if config.Version {
@@ -145,7 +304,19 @@ func ParseOptions() (Options, error) {
 	}
 
 	if config.ConfigPath != "" {
-		return parseConfig(config.ConfigPath)
+		opts, err := parseConfig(config.ConfigPath)
+		opts.Check = config.Check
+		opts.DryRun = config.DryRun
+		opts.Verbose = config.Verbose
+		opts.Watch = config.Watch
+		opts.Explain = config.Explain
+		opts.Format = config.Format
+		opts.ScaffoldMissing = config.Scaffold
+		if config.Parallel != 0 {
+			opts.Parallel = config.Parallel
+		}
+		setCacheDir(&opts, config)
+		return opts, err
 	}
 
 	if err != nil {
@@ -158,7 +329,77 @@ func ParseOptions() (Options, error) {
 		return Options{}, err
 	}
 
-	return parseFlags(config.Flags)
+	opts, err := parseFlags(config.Flags)
+	opts.Check = config.Check
+	opts.DryRun = config.DryRun
+	opts.Parallel = config.Parallel
+	opts.Verbose = config.Verbose
+	opts.Watch = config.Watch
+	opts.Explain = config.Explain
+	opts.Format = config.Format
+	opts.ScaffoldMissing = config.Scaffold
+	setCacheDir(&opts, config)
+	return opts, err
+}
+
+// setCacheDir enables cache only in directory set by flag or config
+func setCacheDir(opts *Options, config Config) {
+	opts.NoCache = config.NoCache
+	if config.CacheDir != "" {
+		opts.CacheDir = config.CacheDir
+	}
+}
+
+func parseFunctionsCommand(args []string) (Options, error) {
+	var config FunctionsConfig
+	parser := flags.NewParser(&config, flags.HelpFlag|flags.PassDoubleDash)
+	parser.Usage = FunctionsCommand + " [OPTIONS]"
+
+	_, err := parser.ParseArgs(args)
+	if err != nil {
+		var flagsErr *flags.Error
+		if errors.As(err, &flagsErr) && flagsErr.Type == flags.ErrHelp {
+			fmt.Println(flagsErr.Message)
+			os.Exit(0)
+		}
+
+		return Options{}, err
+	}
+
+	var opts Options
+	if config.ConfigPath != "" {
+		opts, err = parseConfig(config.ConfigPath)
+		if err != nil {
+			return Options{}, err
+		}
+	}
+
+	for _, opt := range config.UserCFSources {
+		source, alias := parseSourceOption(opt)
+		opts.ConversionFunctions = append(opts.ConversionFunctions, ConversionFunction{
+			Source: source,
+			Alias:  alias,
+		})
+	}
+
+	opts.ListFunctions = true
+	opts.FunctionsType = config.Type
+	opts.Format = config.Format
+
+	return opts, nil
+}
+
+func parseFunctionsSources(sources []string) []ConversionFunction {
+	res := make([]ConversionFunction, 0, len(sources))
+	for _, opt := range sources {
+		source, alias := parseSourceOption(opt)
+		res = append(res, ConversionFunction{
+			Source: source,
+			Alias:  alias,
+		})
+	}
+
+	return res
 }
 
 func parseSourceOption(optSource string) (string, string) {
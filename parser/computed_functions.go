@@ -0,0 +1,82 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/underbek/datamapper/diskcache"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+)
+
+// ParseComputedFunctionsByPackage returns computed functions of package by source or import path
+func (s *Session) ParseComputedFunctionsByPackage(lg logger.Logger, source string,
+) ([]models.ConversionFunction, error) {
+	dir, err := sourceDir(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ParseComputedFunctions(lg, dir)
+}
+
+// ParseComputedFunctions returns functions of source which take whole struct model and return a value
+// with or without error. Functions are sorted by names, functions with the same signature are all returned
+func (s *Session) ParseComputedFunctions(lg logger.Logger, source string) ([]models.ConversionFunction, error) {
+	absSourcePath, err := filepath.Abs(source)
+	if err != nil {
+		return nil, err
+	}
+
+	s.addDir(absSourcePath)
+
+	return s.computed.get(absSourcePath, func() ([]models.ConversionFunction, error) {
+		var res []models.ConversionFunction
+		if s.loadDisk(diskcache.KindComputed, absSourcePath, &res) {
+			return res, nil
+		}
+
+		res, err := s.parseComputedFunctions(lg, source, absSourcePath)
+		if err != nil {
+			return nil, err
+		}
+
+		s.saveDisk(lg, diskcache.KindComputed, absSourcePath, res)
+
+		return res, nil
+	})
+}
+
+func (s *Session) parseComputedFunctions(lg logger.Logger, source, absSourcePath string,
+) ([]models.ConversionFunction, error) {
+	pkg, err := s.loadPackage(lg, source)
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.track(nil, &s.stats.FunctionsDuration, time.Now())
+
+	if pkg.Types == nil {
+		return nil, fmt.Errorf("%w: package %s hasn't type", ErrNotFoundType, pkg.Name)
+	}
+
+	var res []models.ConversionFunction
+	err = eachFunction(pkg, absSourcePath, func(funcs models.Functions) {
+		// functions with union constraints have several keys, they are not computed functions
+		if len(funcs) != 1 {
+			return
+		}
+
+		for _, function := range funcs {
+			if function.FromType.Kind == models.StructType && function.TypeParam == models.NoTypeParam {
+				res = append(res, function)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -1,17 +1,17 @@
 package parser
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"go/build"
 	"go/types"
-	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/underbek/datamapper/diskcache"
 	"github.com/underbek/datamapper/logger"
 	"github.com/underbek/datamapper/models"
-	"github.com/underbek/datamapper/utils"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -21,54 +21,92 @@ var (
 	ErrUndefinedType = errors.New("undefined type error")
 )
 
-var (
-	conversionFunctionsCache = make(map[string]models.Functions)
-)
-
 func ParseConversionFunctionsByPackage(lg logger.Logger, source string) (models.Functions, error) {
-	_, err := os.Stat(source)
-	if err == nil {
-		return ParseConversionFunctions(lg, source)
-	}
+	return NewSession(context.Background()).ParseConversionFunctionsByPackage(lg, source)
+}
 
-	if !os.IsNotExist(err) {
-		return nil, err
-	}
+func ParseConversionFunctions(lg logger.Logger, source string) (models.Functions, error) {
+	return NewSession(context.Background()).ParseConversionFunctions(lg, source)
+}
 
-	wd, err := os.Getwd()
+func (s *Session) ParseConversionFunctionsByPackage(lg logger.Logger, source string) (models.Functions, error) {
+	dir, err := sourceDir(source)
 	if err != nil {
 		return nil, err
 	}
 
-	p, err := build.Import(source, wd, build.FindOnly)
-	if err != nil {
-		return nil, err
-	}
+	return s.ParseConversionFunctions(lg, dir)
+}
 
-	return ParseConversionFunctions(lg, p.Dir)
+// functionEntry is stored in disk cache instead of functions map with interface keys
+type functionEntry struct {
+	Key      models.ConversionFunctionKey
+	Function models.ConversionFunction
 }
 
-func ParseConversionFunctions(lg logger.Logger, source string) (models.Functions, error) {
+func (s *Session) ParseConversionFunctions(lg logger.Logger, source string) (models.Functions, error) {
 	absSourcePath, err := filepath.Abs(source)
 	if err != nil {
 		return nil, err
 	}
 
-	if funcs, ok := conversionFunctionsCache[absSourcePath]; ok {
+	s.addDir(absSourcePath)
+
+	return s.functions.get(absSourcePath, func() (models.Functions, error) {
+		var entries []functionEntry
+		if s.loadDisk(diskcache.KindFunctions, absSourcePath, &entries) {
+			funcs := make(models.Functions, len(entries))
+			for _, entry := range entries {
+				funcs[entry.Key] = entry.Function
+			}
+
+			return funcs, nil
+		}
+
+		funcs, err := s.parseConversionFunctions(lg, source, absSourcePath)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = make([]functionEntry, 0, len(funcs))
+		for key, function := range funcs {
+			entries = append(entries, functionEntry{Key: key, Function: function})
+		}
+
+		s.saveDisk(lg, diskcache.KindFunctions, absSourcePath, entries)
+
 		return funcs, nil
-	}
+	})
+}
 
-	pkg, err := utils.LoadPackage(lg, source)
+func (s *Session) parseConversionFunctions(lg logger.Logger, source, absSourcePath string,
+) (models.Functions, error) {
+	pkg, err := s.loadPackage(lg, source)
 	if err != nil {
 		return nil, err
 	}
 
+	defer s.track(nil, &s.stats.FunctionsDuration, time.Now())
+
 	if pkg.Types == nil {
 		return nil, fmt.Errorf("%w: package %s hasn't type", ErrNotFoundType, pkg.Name)
 	}
 
 	funcs := make(models.Functions)
+	err = eachFunction(pkg, absSourcePath, func(currentFuncs models.Functions) {
+		for key, function := range currentFuncs {
+			funcs[key] = function
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return funcs, nil
+}
 
+// eachFunction calls fn with conversion functions of every exported function of source in order of names
+func eachFunction(pkg *packages.Package, absSourcePath string, fn func(funcs models.Functions)) error {
 	names := pkg.Types.Scope().Names()
 	for _, name := range names {
 		obj := pkg.Types.Scope().Lookup(name)
@@ -87,19 +125,15 @@ func ParseConversionFunctions(lg logger.Logger, source string) (models.Functions
 			continue
 		}
 
-		currentFuncs, err := parseFunction(pkg, f)
+		funcs, err := parseFunction(pkg, f)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		for key, function := range currentFuncs {
-			funcs[key] = function
-		}
+		fn(funcs)
 	}
 
-	conversionFunctionsCache[absSourcePath] = funcs
-
-	return funcs, nil
+	return nil
 }
 
 func parseFunction(pkg *packages.Package, f *types.Func) (models.Functions, error) {
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -563,3 +564,30 @@ func Test_CFParseByDefaultPackage(t *testing.T) {
 		require.Equal(t, value, embedCf[key])
 	}
 }
+
+func Test_ParseComputedFunctions(t *testing.T) {
+	res, err := NewSession(context.Background()).
+		ParseComputedFunctionsByPackage(logger.New(), "../_test_data/mapper/computed/computed")
+	require.NoError(t, err)
+
+	pkg := models.Package{
+		Name: "computed",
+		Path: "github.com/underbek/datamapper/_test_data/mapper/computed/computed",
+	}
+	user := models.Type{
+		Name: "User",
+		Package: models.Package{
+			Name: "domain",
+			Path: "github.com/underbek/datamapper/_test_data/mapper/computed/domain",
+		},
+		Kind: models.StructType,
+	}
+	pointerUser := user
+	pointerUser.Pointer = true
+
+	assert.Equal(t, []models.ConversionFunction{
+		{Name: "Age", Package: pkg, FromType: pointerUser, ToType: models.Type{Name: "int"}, WithError: true},
+		{Name: "FullName", Package: pkg, FromType: user, ToType: models.Type{Name: "string"}},
+		{Name: "Initials", Package: pkg, FromType: user, ToType: models.Type{Name: "string"}},
+	}, res)
+}
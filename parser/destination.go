@@ -1,10 +1,14 @@
 package parser
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"go/token"
+	"path/filepath"
 	"strings"
 
+	"github.com/underbek/datamapper/diskcache"
 	"github.com/underbek/datamapper/logger"
 	"github.com/underbek/datamapper/models"
 	"github.com/underbek/datamapper/utils"
@@ -14,12 +18,49 @@ import (
 var ErrParseError = errors.New("parse error")
 
 func ParseDestinationPackage(lg logger.Logger, destination string) (models.Package, error) {
-	pkg, err := utils.LoadPackage(lg, destination)
+	return NewSession(context.Background()).ParseDestinationPackage(lg, destination)
+}
+
+// ParsePackage returns package by source path or import path
+func ParsePackage(lg logger.Logger, source string) (models.Package, error) {
+	return NewSession(context.Background()).ParsePackage(lg, source)
+}
+
+func (s *Session) ParseDestinationPackage(lg logger.Logger, destination string) (models.Package, error) {
+	absSourcePath, err := filepath.Abs(utils.ClearFileName(destination))
+	if err != nil {
+		return models.Package{}, err
+	}
+
+	s.addDir(absSourcePath)
+
+	var res models.Package
+	if s.loadDisk(diskcache.KindPackage, absSourcePath, &res) {
+		return res, nil
+	}
+
+	pkg, err := s.loadPackage(lg, destination)
+	if err != nil {
+		return models.Package{}, err
+	}
+
+	res, err = generateModelPackage(pkg)
+	if err != nil {
+		return models.Package{}, err
+	}
+
+	s.saveDisk(lg, diskcache.KindPackage, absSourcePath, res)
+
+	return res, nil
+}
+
+func (s *Session) ParsePackage(lg logger.Logger, source string) (models.Package, error) {
+	dir, err := sourceDir(source)
 	if err != nil {
 		return models.Package{}, err
 	}
 
-	return generateModelPackage(pkg)
+	return s.ParseDestinationPackage(lg, dir)
 }
 
 func generateModelPackage(pkg *packages.Package) (models.Package, error) {
@@ -44,3 +85,27 @@ func getPackageNameByPath(path string) string {
 	names := strings.Split(path, "/")
 	return names[len(names)-1]
 }
+
+// FunctionPosition returns position of package level function by package source path or import path
+func (s *Session) FunctionPosition(lg logger.Logger, source, name string) (token.Position, error) {
+	dir, err := sourceDir(source)
+	if err != nil {
+		return token.Position{}, err
+	}
+
+	pkg, err := s.loadPackage(lg, dir)
+	if err != nil {
+		return token.Position{}, err
+	}
+
+	if pkg.Types == nil {
+		return token.Position{}, fmt.Errorf("%w: package %s hasn't type", ErrNotFoundType, source)
+	}
+
+	obj := pkg.Types.Scope().Lookup(name)
+	if obj == nil {
+		return token.Position{}, fmt.Errorf("%w: function %s in package %s", ErrNotFoundType, name, source)
+	}
+
+	return pkg.Fset.Position(obj.Pos()), nil
+}
@@ -1,60 +1,78 @@
 package parser
 
 import (
-	"go/build"
+	"context"
+	"go/token"
 	"go/types"
-	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/underbek/datamapper/diskcache"
 	"github.com/underbek/datamapper/logger"
 	"github.com/underbek/datamapper/models"
-	"github.com/underbek/datamapper/utils"
-)
-
-var (
-	modelsCache = make(map[string]map[string]models.Struct)
 )
 
 func ParseModelsByPackage(lg logger.Logger, source string) (map[string]models.Struct, error) {
-	_, err := os.Stat(source)
-	if err == nil {
-		return ParseModels(lg, source)
-	}
-
-	if !os.IsNotExist(err) {
-		return nil, err
-	}
+	return NewSession(context.Background()).ParseModelsByPackage(lg, source)
+}
 
-	wd, err := os.Getwd()
-	if err != nil {
-		return nil, err
-	}
+func ParseModels(lg logger.Logger, source string) (map[string]models.Struct, error) {
+	return NewSession(context.Background()).ParseModels(lg, source)
+}
 
-	p, err := build.Import(source, wd, build.FindOnly)
+func (s *Session) ParseModelsByPackage(lg logger.Logger, source string) (map[string]models.Struct, error) {
+	dir, err := sourceDir(source)
 	if err != nil {
 		return nil, err
 	}
 
-	return ParseModels(lg, p.Dir)
+	return s.ParseModels(lg, dir)
 }
 
-func ParseModels(lg logger.Logger, source string) (map[string]models.Struct, error) {
+func (s *Session) ParseModels(lg logger.Logger, source string) (map[string]models.Struct, error) {
 	absSourcePath, err := filepath.Abs(source)
 	if err != nil {
 		return nil, err
 	}
 
-	if structs, ok := modelsCache[absSourcePath]; ok {
-		return structs, nil
-	}
+	s.addDir(absSourcePath)
+
+	return s.models.get(absSourcePath, func() (map[string]models.Struct, error) {
+		var entry modelsEntry
+		if s.loadDisk(diskcache.KindModels, absSourcePath, &entry) {
+			s.positions.add(entry.Positions)
+			return entry.Structs, nil
+		}
+
+		structs, positions, err := s.parseModels(lg, source, absSourcePath)
+		if err == nil {
+			s.positions.add(positions)
+			s.saveDisk(lg, diskcache.KindModels, absSourcePath, modelsEntry{Structs: structs, Positions: positions})
+		}
+
+		return structs, err
+	})
+}
 
-	pkg, err := utils.LoadPackage(lg, source)
+// modelsEntry is a disk cache entry of parsed models
+type modelsEntry struct {
+	Structs   map[string]models.Struct
+	Positions map[string]token.Position
+}
+
+// parseModels returns exported structs of source and positions of their fields by fieldKey
+func (s *Session) parseModels(lg logger.Logger, source, absSourcePath string,
+) (map[string]models.Struct, map[string]token.Position, error) {
+	pkg, err := s.loadPackage(lg, source)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	defer s.track(nil, &s.stats.ModelsDuration, time.Now())
+
 	structs := make(map[string]models.Struct)
+	positions := make(map[string]token.Position)
 
 	names := pkg.Types.Scope().Names()
 	for _, name := range names {
@@ -88,13 +106,15 @@ func ParseModels(lg logger.Logger, source string) (map[string]models.Struct, err
 			field := currStruct.Field(i)
 			tts, err := parseType(field.Type())
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			if len(tts) != 1 {
 				continue
 			}
 
+			positions[fieldKey(pkg.PkgPath, currType.Name(), field.Name())] = pkg.Fset.Position(field.Pos())
+
 			fields = append(fields, models.Field{
 				Name: field.Name(),
 				Type: tts[0].Type,
@@ -115,7 +135,5 @@ func ParseModels(lg logger.Logger, source string) (map[string]models.Struct, err
 		}
 	}
 
-	modelsCache[absSourcePath] = structs
-
-	return structs, nil
+	return structs, positions, nil
 }
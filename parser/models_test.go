@@ -249,6 +249,89 @@ func Test_ParseModelByPackage(t *testing.T) {
 	}
 }
 
+func Test_ParseModelWithTagOptions(t *testing.T) {
+	res, err := ParseModelsByPackage(logger.New(), "../_test_data/mapper/computed/dto")
+	require.NoError(t, err)
+
+	profile := res["Profile"]
+	var tags [][]models.Tag
+	profile.Fields.Range(func(field models.Field) {
+		tags = append(tags, field.Tags)
+	})
+
+	assert.Equal(t, [][]models.Tag{
+		{{Name: "json", Value: "id"}},
+		{{Name: "json", Value: "full_name", Options: map[string]string{"compute": "FullName"}}},
+		{{Name: "json", Value: "initials"}},
+		{{Name: "json", Value: "age", Options: map[string]string{"compute": "Age"}}},
+	}, tags)
+
+	res, err = ParseModelsByPackage(logger.New(), "../_test_data/mapper/values/dto")
+	require.NoError(t, err)
+
+	user := res["User"]
+	user.Fields.Range(func(field models.Field) {
+		if field.Name == "Name" {
+			assert.Equal(t, map[string]string{"default": `"anonymous"`}, field.Tags[0].Options)
+		}
+	})
+}
+
+func Test_ParseTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		tag      string
+		expected []models.Tag
+	}{
+		{
+			name: "Several tags",
+			tag:  `map:"id" json:"id,omitempty"`,
+			expected: []models.Tag{
+				{Name: "map", Value: "id"},
+				{Name: "json", Value: "id"},
+			},
+		},
+		{
+			name:     "Value with colon",
+			tag:      `gorm:"column:id"`,
+			expected: []models.Tag{{Name: "gorm", Value: "column:id"}},
+		},
+		{
+			name: "String with space",
+			tag:  `json:"name,default=\"a b\"" map:"name"`,
+			expected: []models.Tag{
+				{Name: "json", Value: "name", Options: map[string]string{"default": `"a b"`}},
+				{Name: "map", Value: "name"},
+			},
+		},
+		{
+			name: "String with comma",
+			tag:  `json:"kind,const=\"x,y\",omitempty"`,
+			expected: []models.Tag{
+				{Name: "json", Value: "kind", Options: map[string]string{"const": `"x,y"`}},
+			},
+		},
+		{
+			name: "String with escaped quote",
+			tag:  `json:"kind,const=\"a\\\"b, c\""`,
+			expected: []models.Tag{
+				{Name: "json", Value: "kind", Options: map[string]string{"const": `"a\"b, c"`}},
+			},
+		},
+		{
+			name:     "Without value",
+			tag:      `deprecated json:"id"`,
+			expected: []models.Tag{{Name: "json", Value: "id"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseTag(tt.tag))
+		})
+	}
+}
+
 func Test_ParseModelWithAlias(t *testing.T) {
 	res, err := ParseModels(logger.New(), testPath+"alias_model.go")
 	require.NoError(t, err)
@@ -0,0 +1,388 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/underbek/datamapper/diskcache"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+	"github.com/underbek/datamapper/utils"
+	"golang.org/x/exp/maps"
+	"golang.org/x/tools/go/packages"
+)
+
+// Session caches loaded packages, parsed models, conversion and computed functions by absolute source path.
+// Session is safe for concurrent use, every source is loaded and parsed once.
+type Session struct {
+	ctx       context.Context
+	packages  *cache[*packages.Package]
+	models    *cache[map[string]models.Struct]
+	functions *cache[models.Functions]
+	computed  *cache[[]models.ConversionFunction]
+	positions *positions
+	stats     *Stats
+
+	disk   *diskcache.Cache
+	parent *Session
+	dirsMu sync.Mutex
+	dirs   map[string]struct{}
+}
+
+// Stats are cumulative durations of session parsing, durations of concurrent calls are summed
+type Stats struct {
+	BatchLoads         int64
+	BatchLoadDuration  time.Duration
+	SingleLoads        int64
+	SingleLoadDuration time.Duration
+	ModelsDuration     time.Duration
+	FunctionsDuration  time.Duration
+	DiskHits           int64
+}
+
+func NewSession(ctx context.Context) *Session {
+	return &Session{
+		ctx:       ctx,
+		packages:  &cache[*packages.Package]{},
+		models:    &cache[map[string]models.Struct]{},
+		functions: &cache[models.Functions]{},
+		computed:  &cache[[]models.ConversionFunction]{},
+		positions: &positions{values: make(map[string]token.Position)},
+		stats:     &Stats{},
+		dirs:      make(map[string]struct{}),
+	}
+}
+
+// Track returns session which shares caches with s and records its own parsed directories.
+// Directories are recorded by s too
+func (s *Session) Track() *Session {
+	return &Session{
+		ctx:       s.ctx,
+		packages:  s.packages,
+		models:    s.models,
+		functions: s.functions,
+		computed:  s.computed,
+		positions: s.positions,
+		stats:     s.stats,
+		disk:      s.disk,
+		parent:    s,
+		dirs:      make(map[string]struct{}),
+	}
+}
+
+// SetDiskCache sets persistent cache of parsed models, conversion functions and packages.
+// It must be set before parsing
+func (s *Session) SetDiskCache(disk *diskcache.Cache) {
+	s.disk = disk
+}
+
+func (s *Session) Context() context.Context {
+	return s.ctx
+}
+
+// Dirs returns sorted package directories of all parsed sources
+func (s *Session) Dirs() []string {
+	s.dirsMu.Lock()
+	defer s.dirsMu.Unlock()
+
+	res := make([]string, 0, len(s.dirs))
+	for dir := range s.dirs {
+		res = append(res, dir)
+	}
+
+	sort.Strings(res)
+	return res
+}
+
+func (s *Session) addDir(absSourcePath string) {
+	s.dirsMu.Lock()
+	defer s.dirsMu.Unlock()
+
+	s.dirs[diskcache.SourceDir(absSourcePath)] = struct{}{}
+
+	if s.parent != nil {
+		s.parent.addDir(absSourcePath)
+	}
+}
+
+// Evict removes cached packages, models, conversion and computed functions of directories
+// and of packages which import them, so they are parsed again. Evicted directories are returned sorted.
+// Evict must not be called concurrently with parsing
+func (s *Session) Evict(dirs ...string) []string {
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	evicted := make(map[string]struct{}, len(dirs))
+	for _, dir := range dirs {
+		evicted[dir] = struct{}{}
+	}
+
+	for dir := range s.importers(evicted) {
+		evicted[dir] = struct{}{}
+	}
+
+	if s.disk != nil {
+		for _, dir := range s.disk.Dependents(maps.Keys(evicted)...) {
+			evicted[dir] = struct{}{}
+		}
+
+		s.disk.Invalidate(maps.Keys(evicted)...)
+	}
+
+	s.packages.evict(evicted)
+	s.models.evict(evicted)
+	s.functions.evict(evicted)
+	s.computed.evict(evicted)
+
+	res := maps.Keys(evicted)
+	sort.Strings(res)
+	return res
+}
+
+// FieldPosition returns position of field declaration of parsed struct model.
+// Position is invalid if the model is not parsed by the session
+func (s *Session) FieldPosition(model models.Type, fieldName string) token.Position {
+	return s.positions.get(fieldKey(model.Package.Path, model.Name, fieldName))
+}
+
+func fieldKey(pkgPath, structName, fieldName string) string {
+	return pkgPath + "." + structName + "." + fieldName
+}
+
+// positions are positions of fields of parsed models by fieldKey
+type positions struct {
+	mu     sync.RWMutex
+	values map[string]token.Position
+}
+
+func (p *positions) add(values map[string]token.Position) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key, pos := range values {
+		p.values[key] = pos
+	}
+}
+
+func (p *positions) get(key string) token.Position {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.values[key]
+}
+
+// loadDisk loads value from disk cache. Values are stored after parsing by saveDisk
+func (s *Session) loadDisk(kind, absSourcePath string, v any) bool {
+	if s.disk == nil || !s.disk.Load(kind, absSourcePath, v) {
+		return false
+	}
+
+	atomic.AddInt64(&s.stats.DiskHits, 1)
+	return true
+}
+
+func (s *Session) saveDisk(lg logger.Logger, kind, absSourcePath string, v any) {
+	if s.disk == nil {
+		return
+	}
+
+	if err := s.disk.Save(kind, absSourcePath, v); err != nil {
+		lg.Warn(fmt.Errorf("save %s of %s to cache error: %w", kind, absSourcePath, err))
+	}
+}
+
+type cacheEntry[T any] struct {
+	once  sync.Once
+	value T
+	err   error
+}
+
+type cache[T any] struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry[T]
+}
+
+// get returns cached value or loads it. Concurrent calls by the same key wait for the first load
+func (c *cache[T]) get(key string, load func() (T, error)) (T, error) {
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*cacheEntry[T])
+	}
+
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry[T]{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.value, entry.err = load()
+	})
+
+	return entry.value, entry.err
+}
+
+func (c *cache[T]) set(key string, value T) {
+	_, _ = c.get(key, func() (T, error) {
+		return value, nil
+	})
+}
+
+func (c *cache[T]) has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.entries[key]
+	return ok
+}
+
+// evict removes entries of sources from directories
+func (c *cache[T]) evict(dirs map[string]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if _, ok := dirs[sourceKeyDir(key)]; ok {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// importers returns directories of loaded packages which import packages from dirs directly or indirectly
+func (s *Session) importers(dirs map[string]struct{}) map[string]struct{} {
+	s.packages.mu.Lock()
+	defer s.packages.mu.Unlock()
+
+	imports := make(map[*packages.Package]bool)
+	var importsDirs func(pkg *packages.Package) bool
+	importsDirs = func(pkg *packages.Package) bool {
+		if res, ok := imports[pkg]; ok {
+			return res
+		}
+
+		imports[pkg] = false
+		for _, imp := range pkg.Imports {
+			if _, ok := dirs[packageDir(imp)]; ok || importsDirs(imp) {
+				imports[pkg] = true
+				return true
+			}
+		}
+
+		return false
+	}
+
+	res := make(map[string]struct{})
+	for key, entry := range s.packages.entries {
+		if entry.value != nil && importsDirs(entry.value) {
+			res[key] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+// sourceKeyDir returns package directory of cache key. Keys are absolute paths of directories or go files
+func sourceKeyDir(key string) string {
+	if strings.HasSuffix(key, ".go") {
+		return filepath.Dir(key)
+	}
+
+	return key
+}
+
+func packageDir(pkg *packages.Package) string {
+	if len(pkg.GoFiles) == 0 {
+		return ""
+	}
+
+	return filepath.Dir(pkg.GoFiles[0])
+}
+
+func (s *Session) loadPackage(lg logger.Logger, source string) (*packages.Package, error) {
+	absSourcePath, err := filepath.Abs(utils.ClearFileName(source))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.packages.get(absSourcePath, func() (*packages.Package, error) {
+		defer s.track(&s.stats.SingleLoads, &s.stats.SingleLoadDuration, time.Now())
+		return utils.LoadPackage(s.ctx, lg, absSourcePath)
+	})
+}
+
+// Preload loads packages of all sources by one call, so shared dependencies are type checked once.
+// Sources which cannot be loaded in the batch are loaded separately on demand.
+func (s *Session) Preload(lg logger.Logger, sources []string) {
+	var dirs []string
+	uniq := make(map[string]struct{})
+	for _, source := range sources {
+		dir, err := sourceDir(utils.ClearFileName(source))
+		if err != nil {
+			continue
+		}
+
+		dir, err = filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := uniq[dir]; ok || s.packages.has(dir) {
+			continue
+		}
+
+		// parsed sources of unchanged packages are read from disk cache without loading
+		if s.disk != nil && s.disk.Has(dir) {
+			continue
+		}
+
+		uniq[dir] = struct{}{}
+		dirs = append(dirs, dir)
+	}
+
+	if len(dirs) == 0 {
+		return
+	}
+
+	defer s.track(&s.stats.BatchLoads, &s.stats.BatchLoadDuration, time.Now())
+
+	pkgs, err := utils.LoadPackages(s.ctx, lg, dirs)
+	if err != nil {
+		lg.Warn(fmt.Errorf("batch load packages error: %w", err))
+		return
+	}
+
+	for dir, pkg := range pkgs {
+		if _, ok := uniq[dir]; ok {
+			s.packages.set(dir, pkg)
+		}
+	}
+}
+
+func (s *Session) Stats() Stats {
+	return Stats{
+		BatchLoads:         atomic.LoadInt64(&s.stats.BatchLoads),
+		BatchLoadDuration:  time.Duration(atomic.LoadInt64((*int64)(&s.stats.BatchLoadDuration))),
+		SingleLoads:        atomic.LoadInt64(&s.stats.SingleLoads),
+		SingleLoadDuration: time.Duration(atomic.LoadInt64((*int64)(&s.stats.SingleLoadDuration))),
+		ModelsDuration:     time.Duration(atomic.LoadInt64((*int64)(&s.stats.ModelsDuration))),
+		FunctionsDuration:  time.Duration(atomic.LoadInt64((*int64)(&s.stats.FunctionsDuration))),
+		DiskHits:           atomic.LoadInt64(&s.stats.DiskHits),
+	}
+}
+
+func (s *Session) track(count *int64, duration *time.Duration, start time.Time) {
+	if count != nil {
+		atomic.AddInt64(count, 1)
+	}
+
+	atomic.AddInt64((*int64)(duration), int64(time.Since(start)))
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/underbek/datamapper/logger"
+)
+
+func Test_SessionPreload(t *testing.T) {
+	lg := logger.New()
+	session := NewSession(context.Background())
+
+	session.Preload(lg, []string{
+		"../_test_data/mapper/cyclic/domain",
+		"github.com/underbek/datamapper/_test_data/mapper/cyclic/dto",
+		"../_test_data/mapper/recursive_containers/domain/models.go",
+		"../_test_data/not_exists/convertor.go",
+	})
+
+	stats := session.Stats()
+	assert.Equal(t, int64(1), stats.BatchLoads)
+
+	for _, source := range []string{
+		"../_test_data/mapper/cyclic/domain",
+		"../_test_data/mapper/cyclic/dto",
+		"github.com/underbek/datamapper/_test_data/mapper/recursive_containers/domain",
+	} {
+		structs, err := session.ParseModelsByPackage(lg, source)
+		require.NoError(t, err)
+		assert.NotEmpty(t, structs)
+	}
+
+	assert.Equal(t, int64(0), session.Stats().SingleLoads)
+
+	structs, err := session.ParseModels(lg, "../_test_data/mapper/recursive_containers/dto")
+	require.NoError(t, err)
+	assert.Contains(t, structs, "Basket")
+	assert.Equal(t, int64(1), session.Stats().SingleLoads)
+}
+
+func Test_SessionEvict(t *testing.T) {
+	const evictPath = "../_test_data/generated/evict"
+	defer func() {
+		require.NoError(t, os.RemoveAll(evictPath))
+	}()
+
+	writeModels := func(pkg, content string) string {
+		dir := filepath.Join(evictPath, pkg)
+		require.NoError(t, os.MkdirAll(dir, 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "models.go"), []byte("package "+pkg+"\n\n"+content), 0600))
+
+		abs, err := filepath.Abs(dir)
+		require.NoError(t, err)
+		return abs
+	}
+
+	user := writeModels("user", "type User struct {\n\tID int64\n}\n")
+	order := writeModels("order", "import \"github.com/underbek/datamapper/_test_data/generated/evict/user\"\n\n"+
+		"type Order struct {\n\tUser user.User\n}\n")
+
+	lg := logger.New()
+	session := NewSession(context.Background())
+	for _, source := range []string{user, order, "../_test_data/mapper/recursive_containers/dto"} {
+		_, err := session.ParseModels(lg, source)
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, int64(3), session.Stats().SingleLoads)
+
+	writeModels("user", "type User struct {\n\tID int64\n\tName string\n}\n")
+	assert.Equal(t, []string{order, user}, session.Evict(user))
+
+	structs, err := session.ParseModels(lg, user)
+	require.NoError(t, err)
+	assert.Equal(t, 2, structs["User"].Fields.Len())
+
+	for _, source := range []string{order, "../_test_data/mapper/recursive_containers/dto"} {
+		_, err = session.ParseModels(lg, source)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int64(5), session.Stats().SingleLoads)
+}
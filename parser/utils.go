@@ -2,12 +2,39 @@ package parser
 
 import (
 	"fmt"
+	"go/build"
 	"go/types"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/underbek/datamapper/models"
 )
 
+// sourceDir returns source path if it exists or directory of package by import path
+func sourceDir(source string) (string, error) {
+	_, err := os.Stat(source)
+	if err == nil {
+		return source, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	p, err := build.Import(source, wd, build.FindOnly)
+	if err != nil {
+		return "", err
+	}
+
+	return p.Dir, nil
+}
+
 type Type struct {
 	models.Type
 	generic bool
@@ -203,26 +230,100 @@ func parseTag(tag string) []models.Tag {
 	}
 
 	value := strings.Trim(tag, "`")
-	textTags := strings.Split(value, " ")
 
-	tags := make([]models.Tag, 0, len(textTags))
-	for _, textTag := range textTags {
-		sepIndex := strings.Index(textTag, ":")
+	tags := make([]models.Tag, 0, strings.Count(value, ":"))
+	for value != "" {
+		value = strings.TrimLeft(value, " ")
+		sepIndex := strings.Index(value, ":")
 		if sepIndex == -1 {
-			continue
+			break
 		}
 
-		valueTag := strings.Trim(textTag[sepIndex+1:], "\"")
+		// words without values before the name like `foo json:"id"` are skipped
+		name := value[:sepIndex]
+		name = name[strings.LastIndex(name, " ")+1:]
+
+		var tagValue string
+		tagValue, value = cutTagValue(value[sepIndex+1:])
+		parts := splitTagValue(tagValue)
 
 		tags = append(tags, models.Tag{
-			Name:  textTag[:sepIndex],
-			Value: strings.Split(valueTag, ",")[0],
+			Name:    name,
+			Value:   parts[0],
+			Options: parseTagOptions(parts[1:]),
 		})
 	}
 
 	return tags
 }
 
+// cutTagValue returns unquoted value of tag and rest of tags.
+// Quoted values like "name,const=\"a b\"" are read up to the closing quote, other values up to a space
+func cutTagValue(value string) (string, string) {
+	if !strings.HasPrefix(value, "\"") {
+		res, rest, _ := strings.Cut(value, " ")
+		return res, rest
+	}
+
+	end := 1
+	for end < len(value) && value[end] != '"' {
+		if value[end] == '\\' {
+			end++
+		}
+		end++
+	}
+
+	if end >= len(value) {
+		return strings.Trim(value, "\""), ""
+	}
+
+	quoted := value[:end+1]
+	res, err := strconv.Unquote(quoted)
+	if err != nil {
+		res = strings.Trim(quoted, "\"")
+	}
+
+	return res, value[end+1:]
+}
+
+// splitTagValue splits value of tag by commas outside of Go string literals of options like const="x,y"
+func splitTagValue(value string) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(value); i++ {
+		switch {
+		case quoted && value[i] == '\\':
+			i++
+		case value[i] == '"':
+			quoted = !quoted
+		case value[i] == ',' && !quoted:
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, value[start:])
+}
+
+// parseTagOptions returns key=value options of tag, options without value like omitempty are skipped
+func parseTagOptions(parts []string) map[string]string {
+	var options map[string]string
+	for _, part := range parts {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+
+		if options == nil {
+			options = make(map[string]string)
+		}
+
+		options[key] = value
+	}
+
+	return options
+}
+
 func isErrorType(t types.Type) (bool, error) {
 	errTypes, err := parseType(t)
 	if err != nil {
@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+)
+
+var ErrInvalidValue = errors.New("invalid field value error")
+
+// names of check source are not expected in values
+const (
+	checkPackageName = "_datamapperPackage"
+	checkModelName   = "_datamapperModel"
+)
+
+// CheckFieldValue checks by go/types that value is a Go expression which can be assigned into field of model
+// by path of Go names. Value can use only predeclared identifiers like true and nil
+func (s *Session) CheckFieldValue(lg logger.Logger, model models.Type, fieldPath, value string) error {
+	expr, err := parser.ParseExpr(value)
+	if err != nil {
+		return fmt.Errorf("%w: %s of %s.%s is not expression: %s", ErrInvalidValue, value, model.Name, fieldPath, err)
+	}
+
+	// values are written into convertors as is, so qualified names of other packages are not resolved
+	if pkgName := qualifier(expr); pkgName != "" {
+		return fmt.Errorf(
+			"%w: %s of %s.%s uses package %s, values can use only predeclared identifiers and literals",
+			ErrInvalidValue, value, model.Name, fieldPath, pkgName,
+		)
+	}
+
+	dir, err := sourceDir(model.Package.Path)
+	if err != nil {
+		return err
+	}
+
+	pkg, err := s.loadPackage(lg, dir)
+	if err != nil {
+		return err
+	}
+
+	if pkg.Types == nil {
+		return fmt.Errorf("%w: package %s hasn't type", ErrNotFoundType, pkg.Name)
+	}
+
+	source := fmt.Sprintf(
+		"package check\n\nimport %s %q\n\nvar %s %s.%s\n\nfunc _() {\n%s.%s = %s\n}\n",
+		checkPackageName,
+		model.Package.Path,
+		checkModelName,
+		checkPackageName,
+		model.Name,
+		checkModelName,
+		fieldPath,
+		value,
+	)
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "check.go", source, 0)
+	if err != nil {
+		return fmt.Errorf("%w: %s of %s.%s: %s", ErrInvalidValue, value, model.Name, fieldPath, err)
+	}
+
+	conf := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			if path != pkg.Types.Path() {
+				return nil, fmt.Errorf("%w: %s", ErrNotFoundType, path)
+			}
+
+			return pkg.Types, nil
+		}),
+	}
+
+	if _, err = conf.Check("check", fset, []*ast.File{file}, nil); err != nil {
+		var typeErr types.Error
+		if errors.As(err, &typeErr) {
+			err = errors.New(typeErr.Msg)
+		}
+
+		return fmt.Errorf("%w: %s of %s.%s: %s", ErrInvalidValue, value, model.Name, fieldPath, err)
+	}
+
+	return nil
+}
+
+// qualifier returns the first package name of qualified identifier of expression like dto in dto.KindAdmin
+func qualifier(expr ast.Expr) string {
+	var res string
+	ast.Inspect(expr, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok || res != "" {
+			return res == ""
+		}
+
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			res = ident.Name
+		}
+
+		return res == ""
+	})
+
+	return res
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
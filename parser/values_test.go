@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/underbek/datamapper/logger"
+	"github.com/underbek/datamapper/models"
+)
+
+func Test_CheckFieldValue(t *testing.T) {
+	user := models.Type{
+		Name: "User",
+		Package: models.Package{
+			Name: "dto",
+			Path: "github.com/underbek/datamapper/_test_data/mapper/values/dto",
+		},
+		Kind: models.StructType,
+	}
+
+	tests := []struct {
+		name   string
+		field  string
+		value  string
+		target error
+	}{
+		{name: "Integer", field: "Version", value: "2"},
+		{name: "String into named type", field: "Kind", value: `"admin"`},
+		{name: "Constant expression", field: "Age", value: "6 * 3"},
+		{name: "Wrong type", field: "Version", value: `"2"`, target: ErrInvalidValue},
+		{name: "Overflow", field: "Version", value: "1 << 70", target: ErrInvalidValue},
+		{name: "Undefined identifier", field: "Source", value: "os.Args[0]", target: ErrInvalidValue},
+		{name: "Package constant", field: "Kind", value: "dto.KindAdmin", target: ErrInvalidValue},
+		{name: "Not expression", field: "Source", value: `"api"; x := 1`, target: ErrInvalidValue},
+		{name: "Unknown field", field: "Unknown", value: "1", target: ErrInvalidValue},
+	}
+
+	session := NewSession(context.Background())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := session.CheckFieldValue(logger.New(), user, tt.field, tt.value)
+			if tt.target == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.target)
+		})
+	}
+}
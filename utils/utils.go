@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"path/filepath"
 	"strings"
 
@@ -8,26 +9,19 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
-var (
-	packageCache = make(map[string]*packages.Package)
-)
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedTypes | packages.NeedDeps | packages.NeedImports
 
-func LoadPackage(lg logger.Logger, source string) (*packages.Package, error) {
+func LoadPackage(ctx context.Context, lg logger.Logger, source string) (*packages.Package, error) {
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedDeps | packages.NeedImports,
+		Context: ctx,
+		Mode:    loadMode,
 	}
 
-	source = ClearFileName(source)
-
-	absSourcePath, err := filepath.Abs(source)
+	absSourcePath, err := filepath.Abs(ClearFileName(source))
 	if err != nil {
 		return nil, err
 	}
 
-	if pkg, ok := packageCache[absSourcePath]; ok {
-		return pkg, nil
-	}
-
 	pkgs, err := packages.Load(cfg, absSourcePath)
 	if err != nil {
 		return nil, err
@@ -39,11 +33,49 @@ func LoadPackage(lg logger.Logger, source string) (*packages.Package, error) {
 		lg.Warn(err)
 	}
 
-	packageCache[absSourcePath] = pkg
-
 	return pkg, nil
 }
 
+// LoadPackages loads packages of directories by one packages.Load call
+// and returns them by directories. Shared dependencies are type checked once.
+func LoadPackages(ctx context.Context, lg logger.Logger, dirs []string) (map[string]*packages.Package, error) {
+	cfg := &packages.Config{
+		Context: ctx,
+		Mode:    loadMode,
+	}
+
+	pkgs, err := packages.Load(cfg, dirs...)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*packages.Package, len(pkgs))
+	for _, pkg := range pkgs {
+		dir := packageDir(pkg)
+		if dir == "" {
+			continue
+		}
+
+		for _, err := range pkg.Errors {
+			lg.Warn(err)
+		}
+
+		res[dir] = pkg
+	}
+
+	return res, nil
+}
+
+func packageDir(pkg *packages.Package) string {
+	for _, files := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles} {
+		if len(files) != 0 {
+			return filepath.Dir(files[0])
+		}
+	}
+
+	return ""
+}
+
 func ClearFileName(source string) string {
 	index := strings.LastIndex(source, ".go")
 	if index != -1 && index == len(source)-3 {